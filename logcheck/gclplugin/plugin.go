@@ -34,8 +34,9 @@ func init() {
 }
 
 type settings struct {
-	Check  map[string]bool `json:"check"`
-	Config string          `json:"config"`
+	Check   map[string]bool   `json:"check"`
+	Config  string            `json:"config"`
+	Options map[string]string `json:"options"`
 }
 
 // New Module Plugin System, see https://golangci-lint.run/plugins/module-plugins/.
@@ -81,6 +82,12 @@ func (l *LogcheckPlugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
 		return nil, fmt.Errorf("parsing config: %v", err)
 	}
 
+	for name, value := range l.settings.Options {
+		if err := analyzer.Flags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("option %s: %v", name, err)
+		}
+	}
+
 	return []*analysis.Analyzer{analyzer}, nil
 }
 
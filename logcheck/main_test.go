@@ -26,10 +26,13 @@ import (
 
 func TestAnalyzer(t *testing.T) {
 	tests := []struct {
-		name        string
-		enabled     map[string]string
-		override    string
-		testPackage string
+		name           string
+		enabled        map[string]string
+		override       string
+		options        map[string]string
+		testPackage    string
+		testData       string
+		suggestedFixes bool
 	}{
 		{
 			name: "Allow unstructured logs",
@@ -84,8 +87,9 @@ func TestAnalyzer(t *testing.T) {
 			testPackage: "importrename",
 		},
 		{
-			name:        "verbose",
-			testPackage: "verbose",
+			name:           "verbose",
+			testPackage:    "verbose",
+			suggestedFixes: true,
 		},
 		{
 			name: "gologr",
@@ -95,6 +99,13 @@ func TestAnalyzer(t *testing.T) {
 			},
 			testPackage: "gologr",
 		},
+		{
+			name: "type aliases",
+			enabled: map[string]string{
+				"with-helpers": "true",
+			},
+			testPackage: "typeAliases",
+		},
 		{
 			name: "contextual",
 			enabled: map[string]string{
@@ -102,12 +113,31 @@ func TestAnalyzer(t *testing.T) {
 			},
 			testPackage: "contextual",
 		},
+		{
+			name: "contextual fixes",
+			enabled: map[string]string{
+				"contextual": "true",
+			},
+			testPackage:    "contextualFix",
+			suggestedFixes: true,
+		},
+		{
+			name:           "context and logger fixes",
+			testPackage:    "contextAndLogger",
+			suggestedFixes: true,
+		},
+		{
+			name:           "context and logger fixes in package main",
+			testPackage:    "contextAndLoggerMain",
+			suggestedFixes: true,
+		},
 		{
 			name: "helpers",
 			enabled: map[string]string{
 				"with-helpers": "true",
 			},
-			testPackage: "helpers",
+			testPackage:    "helpers",
+			suggestedFixes: true,
 		},
 		{
 			name: "Do not allow Verbosity Zero logs",
@@ -116,7 +146,8 @@ func TestAnalyzer(t *testing.T) {
 				"key":             "false",
 				"verbosity-error": "false",
 			},
-			testPackage: "doNotAllowVerbosityZeroLogs",
+			testPackage:    "doNotAllowVerbosityZeroLogs",
+			suggestedFixes: true,
 		},
 		{
 			name: "Allow Verbosity Zero logs",
@@ -145,6 +176,176 @@ func TestAnalyzer(t *testing.T) {
 			},
 			testPackage: "allowBadkeysLogs",
 		},
+		{
+			name: "Bad key fixes",
+			enabled: map[string]string{
+				"structured": "false",
+				"parameters": "false",
+			},
+			testPackage:    "keyFix",
+			suggestedFixes: true,
+		},
+		{
+			name: "Format specifier fixes",
+			options: map[string]string{
+				"error-key": "error",
+			},
+			testPackage:    "formatSpecifierFix",
+			suggestedFixes: true,
+		},
+		{
+			name: "Duplicate keys",
+			enabled: map[string]string{
+				"duplicate-keys": "true",
+			},
+			testPackage: "duplicateKeys",
+		},
+		{
+			name: "Reserved keys",
+			enabled: map[string]string{
+				"reserved-keys": "true",
+			},
+			testPackage: "reservedKeys",
+		},
+		{
+			name: "Reserved keys with error key",
+			enabled: map[string]string{
+				"reserved-keys": "true",
+			},
+			options: map[string]string{
+				"error-key":     "error",
+				"reserved-keys": "err,error",
+			},
+			testPackage: "reservedErrorKey",
+		},
+		{
+			name: "Message",
+			enabled: map[string]string{
+				"message": "true",
+			},
+			testPackage:    "message",
+			suggestedFixes: true,
+		},
+		{
+			name: "Constant message",
+			enabled: map[string]string{
+				"constant-message": "true",
+			},
+			testPackage:    "constantMessage",
+			suggestedFixes: true,
+		},
+		{
+			name: "Key/value pairs in messages",
+			enabled: map[string]string{
+				"message-key-values": "true",
+			},
+			testPackage:    "messageKeyValues",
+			suggestedFixes: true,
+		},
+		{
+			name: "Sensitive data",
+			enabled: map[string]string{
+				"sensitive": "true",
+				"key":       "false",
+			},
+			testPackage: "sensitive",
+		},
+		{
+			name: "Expensive arguments",
+			enabled: map[string]string{
+				"expensive-arguments": "true",
+			},
+			testPackage: "expensiveArguments",
+		},
+		{
+			name: "Key consistency",
+			enabled: map[string]string{
+				"key-consistency": "true",
+			},
+			testPackage: "keyConsistency",
+		},
+		{
+			name: "KObj",
+			enabled: map[string]string{
+				"kobj": "true",
+			},
+			testPackage:    "kobj",
+			suggestedFixes: true,
+		},
+		{
+			name: "Context propagation",
+			enabled: map[string]string{
+				"context-propagation": "true",
+			},
+			testPackage:    "contextPropagation",
+			suggestedFixes: true,
+		},
+		{
+			name: "Context propagation before Go 1.21",
+			enabled: map[string]string{
+				"context-propagation": "true",
+			},
+			testPackage:    "contextPropagationGo120",
+			testData:       "testdata/modules/contextPropagationGo120",
+			suggestedFixes: true,
+		},
+		{
+			name: "Discarded logger",
+			enabled: map[string]string{
+				"discarded-logger": "true",
+			},
+			testPackage:    "discardedLogger",
+			suggestedFixes: true,
+		},
+		{
+			name: "Transitive contextual facts",
+			enabled: map[string]string{
+				"contextual":            "true",
+				"contextual-transitive": "true",
+			},
+			testPackage: "contextualTransitive",
+		},
+		{
+			name:        "TODO through config",
+			override:    "testdata/src/todo/todo_logging",
+			testPackage: "todo",
+		},
+		{
+			name: "TODO budget",
+			enabled: map[string]string{
+				"todo": "true",
+			},
+			options: map[string]string{
+				"todo-budget": "todoBudget=2,0",
+			},
+			testPackage: "todoBudget",
+		},
+		{
+			name: "Goroutines",
+			enabled: map[string]string{
+				"goroutine": "true",
+			},
+			testPackage: "goroutine",
+		},
+		{
+			name: "Package initialization",
+			enabled: map[string]string{
+				"package-init": "true",
+				"structured":   "false",
+			},
+			testPackage: "packageInit",
+		},
+		{
+			name: "Package initialization with calls",
+			enabled: map[string]string{
+				"package-init": "true",
+				"structured":   "false",
+			},
+			options: map[string]string{
+				"package-init-follow-calls": "true",
+			},
+			testPackage: "packageInit/...",
+		},
 		{
 			name: "Detect incomplete fmt.Stringer",
 			enabled: map[string]string{
@@ -152,6 +353,32 @@ func TestAnalyzer(t *testing.T) {
 			},
 			testPackage: "stringer",
 		},
+		{
+			name:           "Convert unstructured logs",
+			testPackage:    "structuredFix",
+			suggestedFixes: true,
+		},
+		{
+			name:           "Deprecations",
+			testPackage:    "deprecations",
+			suggestedFixes: true,
+		},
+		{
+			name: "Deprecations without structured",
+			enabled: map[string]string{
+				"structured": "false",
+			},
+			testPackage:    "deprecationsUnstructured",
+			suggestedFixes: true,
+		},
+		{
+			name: "V().Error fixes",
+			options: map[string]string{
+				"error-key": "error",
+			},
+			testPackage:    "verbosityError",
+			suggestedFixes: true,
+		},
 		{
 			name: "logcheck facts",
 			enabled: map[string]string{
@@ -176,7 +403,18 @@ func TestAnalyzer(t *testing.T) {
 			if tc.override != "" {
 				set("config", tc.override)
 			}
-			analysistest.Run(t, analysistest.TestData(), analyzer, tc.testPackage)
+			for key, value := range tc.options {
+				set(key, value)
+			}
+			testData := analysistest.TestData()
+			if tc.testData != "" {
+				testData = tc.testData
+			}
+			if tc.suggestedFixes {
+				analysistest.RunWithSuggestedFixes(t, testData, analyzer, tc.testPackage)
+				return
+			}
+			analysistest.Run(t, testData, analyzer, tc.testPackage)
 		})
 	}
 }
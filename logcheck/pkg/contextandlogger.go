@@ -0,0 +1,358 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// contextAndLoggerFixes removes the logger parameter from a function which
+// also accepts a context. Inside the function, the logger is retrieved from
+// the context instead. All callers in the package get updated. Callers which
+// do not pass the logger from their context get the context wrapped with
+// klog.NewContext.
+//
+// This is only possible if all uses of the function are calls in the same
+// package. Exported functions and methods are left alone because other
+// packages might call them or, for methods, might have an interface which
+// they implement, see isExportedAPI. Unexported methods can only implement
+// interfaces of the same package, so those get checked.
+func contextAndLoggerFixes(n ast.Node, pass *analysis.Pass) []analysis.SuggestedFix {
+	funcType, ok := n.(*ast.FuncType)
+	if !ok {
+		return nil
+	}
+	decl := funcDeclOf(funcType, pass)
+	if decl == nil || decl.Body == nil {
+		return nil
+	}
+	function, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok || isExportedAPI(decl, pass) || decl.Recv != nil && isInterfaceMethodName(decl.Name.Name, pass) {
+		return nil
+	}
+
+	params := function.Type().(*types.Signature).Params()
+	ctxIndex, loggerIndex := -1, -1
+	for i := 0; i < params.Len(); i++ {
+		switch {
+		case isNamedType(params.At(i).Type(), "context", "Context"):
+			if ctxIndex == -1 {
+				ctxIndex = i
+			}
+		case isNamedType(params.At(i).Type(), "github.com/go-logr/logr", "Logger"):
+			if loggerIndex != -1 {
+				return nil
+			}
+			loggerIndex = i
+		}
+	}
+	if ctxIndex == -1 || loggerIndex == -1 {
+		return nil
+	}
+	fieldIndex := fieldOfParam(funcType.Params.List, loggerIndex)
+	if fieldIndex == -1 {
+		return nil
+	}
+	field := funcType.Params.List[fieldIndex]
+
+	// Replace the parameter with a local variable, if it is used.
+	var edits []analysis.TextEdit
+	logger := params.At(loggerIndex)
+	if usesObject(decl.Body, logger, pass) {
+		ctx := params.At(ctxIndex).Name()
+		if ctx == "" || ctx == "_" || len(decl.Body.List) == 0 {
+			return nil
+		}
+		klog, importEdits, ok := importName(pass, decl.Body.Pos(), "k8s.io/klog/v2", "klog")
+		if !ok {
+			return nil
+		}
+		first := decl.Body.List[0].Pos()
+		indent := strings.Repeat("\t", pass.Fset.Position(first).Column-1)
+		edits = append(edits, importEdits...)
+		edits = append(edits, analysis.TextEdit{
+			Pos:     first,
+			End:     first,
+			NewText: []byte(fmt.Sprintf("%s := %s.FromContext(%s)\n%s", logger.Name(), klog, ctx, indent)),
+		})
+	}
+	fields := make([]ast.Node, 0, len(funcType.Params.List))
+	for _, field := range funcType.Params.List {
+		fields = append(fields, field)
+	}
+	edits = append(edits, removeElement(fields, fieldIndex))
+	if selExpr, ok := field.Type.(*ast.SelectorExpr); ok && !isPackage(selExpr.X, "k8s.io/klog/v2", pass) {
+		if ident, ok := selExpr.X.(*ast.Ident); ok {
+			edits = append(edits, removeImportIfUnused(pass, ident)...)
+		}
+	}
+
+	// Update the callers. Any other use of the function prevents the fix
+	// because the new signature might not be acceptable there.
+	calls := 0
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			callExpr, ok := n.(*ast.CallExpr)
+			if !ok || calledObject(callExpr, pass) != function {
+				return true
+			}
+			callEdits := callerEdits(callExpr, ctxIndex, loggerIndex, pass)
+			if callEdits == nil {
+				return true
+			}
+			calls++
+			edits = append(edits, callEdits...)
+			return true
+		})
+	}
+	if calls != countUses(function, pass) {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Remove the logger parameter, use klog.FromContext instead",
+		TextEdits: uniqueEdits(edits),
+	}}
+}
+
+// isExportedAPI checks whether other packages might use the function. A
+// package main cannot be imported, but its exported methods might still
+// implement an interface from some other package.
+func isExportedAPI(decl *ast.FuncDecl, pass *analysis.Pass) bool {
+	return decl.Name.IsExported() && (pass.Pkg.Name() != "main" || decl.Recv != nil)
+}
+
+// callerEdits removes the logger argument from the call. If that logger
+// is not the one from the context that is passed, then the context gets
+// replaced with klog.NewContext(ctx, logger). Returns nil if this is not
+// possible.
+func callerEdits(callExpr *ast.CallExpr, ctxIndex, loggerIndex int, pass *analysis.Pass) []analysis.TextEdit {
+	if len(callExpr.Args) <= ctxIndex || len(callExpr.Args) <= loggerIndex ||
+		callExpr.Ellipsis.IsValid() && loggerIndex == len(callExpr.Args)-1 {
+		return nil
+	}
+	args := make([]ast.Node, 0, len(callExpr.Args))
+	for _, arg := range callExpr.Args {
+		args = append(args, arg)
+	}
+	edits := []analysis.TextEdit{removeElement(args, loggerIndex)}
+
+	ctx, logger := callExpr.Args[ctxIndex], callExpr.Args[loggerIndex]
+	if isLoggerFromContext(logger, ctx, pass) {
+		return edits
+	}
+	klog, importEdits, ok := importName(pass, callExpr.Pos(), "k8s.io/klog/v2", "klog")
+	if !ok {
+		return nil
+	}
+	edits = append(edits, importEdits...)
+	edits = append(edits, analysis.TextEdit{
+		Pos:     ctx.Pos(),
+		End:     ctx.End(),
+		NewText: []byte(fmt.Sprintf("%s.NewContext(%s, %s)", klog, formatNode(pass.Fset, ctx), formatNode(pass.Fset, logger))),
+	})
+	return edits
+}
+
+// isLoggerFromContext checks whether the logger expression is
+// klog.FromContext(ctx) or a variable which gets initialized with that and
+// never changes.
+func isLoggerFromContext(logger, ctx ast.Expr, pass *analysis.Pass) bool {
+	if ident, ok := logger.(*ast.Ident); ok {
+		variable, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok {
+			return false
+		}
+		values := assignedValues(variable, pass)
+		if len(values) != 1 {
+			return false
+		}
+		logger = values[0]
+	}
+
+	callExpr, ok := logger.(*ast.CallExpr)
+	if !ok || len(callExpr.Args) != 1 {
+		return false
+	}
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "FromContext" || !isPackage(selExpr.X, "k8s.io/klog/v2", pass) {
+		return false
+	}
+	ctxIdent, ok := ctx.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	fromIdent, ok := callExpr.Args[0].(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[fromIdent] == pass.TypesInfo.Uses[ctxIdent] && len(assignedValues(pass.TypesInfo.Uses[ctxIdent], pass)) <= 1
+}
+
+// assignedValues returns all expressions which get assigned to the variable
+// in the package. Assignments of multiple values from a single call are
+// returned as that call, which never matches klog.FromContext.
+func assignedValues(object types.Object, pass *analysis.Pass) []ast.Expr {
+	var values []ast.Expr
+	isVariable := func(expr ast.Expr) bool {
+		ident, ok := expr.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(ident) == object
+	}
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for i, lhs := range n.Lhs {
+					if isVariable(lhs) {
+						values = append(values, n.Rhs[min(i, len(n.Rhs)-1)])
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if isVariable(name) && len(n.Values) > 0 {
+						values = append(values, n.Values[min(i, len(n.Values)-1)])
+					}
+				}
+			case *ast.UnaryExpr:
+				// Taking the address allows modifications
+				// which cannot be tracked.
+				if n.Op == token.AND && isVariable(n.X) {
+					values = append(values, n)
+				}
+			}
+			return true
+		})
+	}
+	return values
+}
+
+// removeElement removes an element from a comma-separated list, including
+// the separator.
+func removeElement(elements []ast.Node, i int) analysis.TextEdit {
+	switch {
+	case i+1 < len(elements):
+		return analysis.TextEdit{Pos: elements[i].Pos(), End: elements[i+1].Pos()}
+	case i > 0:
+		return analysis.TextEdit{Pos: elements[i-1].End(), End: elements[i].End()}
+	default:
+		return analysis.TextEdit{Pos: elements[i].Pos(), End: elements[i].End()}
+	}
+}
+
+// funcDeclOf returns the function declaration with the given type, if there
+// is one.
+func funcDeclOf(funcType *ast.FuncType, pass *analysis.Pass) *ast.FuncDecl {
+	file := fileOf(pass, funcType.Pos())
+	if file == nil {
+		return nil
+	}
+	for _, decl := range file.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Type == funcType {
+			return funcDecl
+		}
+	}
+	return nil
+}
+
+// fieldOfParam returns the index of the field which defines the parameter
+// with the given index. The field must not define any other parameter.
+func fieldOfParam(fields []*ast.Field, param int) int {
+	for i, field := range fields {
+		names := max(len(field.Names), 1)
+		if param < names {
+			if names > 1 {
+				return -1
+			}
+			return i
+		}
+		param -= names
+	}
+	return -1
+}
+
+// isInterfaceMethodName checks whether some interface in the package has a
+// method with the given name.
+func isInterfaceMethodName(name string, pass *analysis.Pass) bool {
+	for ident, object := range pass.TypesInfo.Defs {
+		if function, ok := object.(*types.Func); ok && ident.Name == name {
+			if recv := function.Type().(*types.Signature).Recv(); recv != nil && types.IsInterface(recv.Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// calledObject returns the function or method which gets called directly.
+func calledObject(callExpr *ast.CallExpr, pass *analysis.Pass) types.Object {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		return pass.TypesInfo.Uses[fun]
+	case *ast.SelectorExpr:
+		return pass.TypesInfo.Uses[fun.Sel]
+	}
+	return nil
+}
+
+// usesObject checks whether the object is referenced inside the node.
+func usesObject(node ast.Node, object types.Object, pass *analysis.Pass) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == object {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// countUses counts how often the object is referenced in the package.
+func countUses(object types.Object, pass *analysis.Pass) int {
+	uses := 0
+	for _, o := range pass.TypesInfo.Uses {
+		if o == object {
+			uses++
+		}
+	}
+	return uses
+}
+
+// uniqueEdits sorts the edits and removes duplicates, like the same import
+// added for different call sites in a file.
+func uniqueEdits(edits []analysis.TextEdit) []analysis.TextEdit {
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Pos != edits[j].Pos {
+			return edits[i].Pos < edits[j].Pos
+		}
+		return edits[i].End < edits[j].End
+	})
+	var result []analysis.TextEdit
+	for _, edit := range edits {
+		if len(result) > 0 {
+			last := result[len(result)-1]
+			if last.Pos == edit.Pos && last.End == edit.End && string(last.NewText) == string(edit.NewText) {
+				continue
+			}
+		}
+		result = append(result, edit)
+	}
+	return result
+}
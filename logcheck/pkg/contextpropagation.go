@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// checkForContextPropagation reports klog.Background, klog.TODO,
+// context.Background and context.TODO calls in code which has a context or
+// logger in scope. Those calls lose the logger that the caller passed in.
+func checkForContextPropagation(fexpr *ast.CallExpr, selExpr *ast.SelectorExpr, pass *analysis.Pass) {
+	fName := selExpr.Sel.Name
+	if fName != "Background" && fName != "TODO" {
+		return
+	}
+	var pkgName string
+	switch {
+	case isKlog(selExpr.X, pass):
+		pkgName = "klog"
+	case isPackage(selExpr.X, "context", pass):
+		pkgName = "context"
+	default:
+		return
+	}
+
+	file := fileOf(pass, fexpr.Pos())
+	if file == nil {
+		return
+	}
+	path, _ := astutil.PathEnclosingInterval(file, fexpr.Pos(), fexpr.End())
+	ctx := parameterInScope(path, variableInScope(pass, fexpr.Pos(), "context", "Context", "ctx"))
+	logger := parameterInScope(path, variableInScope(pass, fexpr.Pos(), "github.com/go-logr/logr", "Logger", "logger"))
+	if ctx == nil && logger == nil {
+		return
+	}
+	if pkgName == "context" && isNewContextParent(path, pass) {
+		// klog.NewContext(context.Background(), logger) adds the logger.
+		return
+	}
+
+	variable := ctx
+	if variable == nil {
+		variable = logger
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            fexpr.Pos(),
+		Message:        fmt.Sprintf("%s.%s() ignores the logger in %q and breaks contextual logging.", pkgName, fName, variable.Name()),
+		SuggestedFixes: contextPropagationFixes(fexpr, file, path, pkgName, ctx, logger, pass),
+	})
+}
+
+// contextPropagationFixes suggests the ctx or logger variable as replacement.
+// In a goroutine which was started with a function literal, the context
+// from outside of that function might get canceled while the goroutine
+// still runs, so only its values are used. context.WithoutCancel needs
+// Go 1.21, there is no fix for older code.
+func contextPropagationFixes(fexpr *ast.CallExpr, file *ast.File, path []ast.Node, pkgName string, ctx, logger *types.Var, pass *analysis.Pass) []analysis.SuggestedFix {
+	var replacement string
+	var edits []analysis.TextEdit
+	switch {
+	case pkgName == "klog" && logger != nil:
+		replacement = logger.Name()
+	case pkgName == "klog":
+		replacement = formatNode(pass.Fset, fexpr.Fun.(*ast.SelectorExpr).X) + ".FromContext(" + ctx.Name() + ")"
+	case ctx != nil && inGoroutine(path, ctx):
+		if !goVersionAtLeast(pass, file, "go1.21") {
+			return nil
+		}
+		replacement = formatNode(pass.Fset, fexpr.Fun.(*ast.SelectorExpr).X) + ".WithoutCancel(" + ctx.Name() + ")"
+	case ctx != nil:
+		replacement = ctx.Name()
+	default:
+		klogName, importEdits, ok := importName(pass, fexpr.Pos(), "k8s.io/klog/v2", "klog")
+		if !ok {
+			return nil
+		}
+		edits = importEdits
+		replacement = klogName + ".NewContext(" + formatNode(pass.Fset, fexpr) + ", " + logger.Name() + ")"
+	}
+	edits = append(edits, analysis.TextEdit{
+		Pos:     fexpr.Pos(),
+		End:     fexpr.End(),
+		NewText: []byte(replacement),
+	})
+	return []analysis.SuggestedFix{{
+		Message:   "Use " + replacement,
+		TextEdits: edits,
+	}}
+}
+
+// parameterInScope returns the variable if it is a parameter of one of the
+// functions on the path. Local variables are ignored because they are
+// typically initialized with the calls that this check reports, while
+// parameters get passed in by the caller.
+func parameterInScope(path []ast.Node, variable *types.Var) *types.Var {
+	if variable == nil {
+		return nil
+	}
+	for _, node := range path {
+		var funcType *ast.FuncType
+		switch node := node.(type) {
+		case *ast.FuncDecl:
+			funcType = node.Type
+		case *ast.FuncLit:
+			funcType = node.Type
+		default:
+			continue
+		}
+		if funcType.Params.Pos() <= variable.Pos() && variable.Pos() < funcType.Params.End() {
+			return variable
+		}
+	}
+	return nil
+}
+
+// isNewContextParent checks whether the call at the start of the path is the
+// parent context in a klog.NewContext or logr.NewContext call.
+func isNewContextParent(path []ast.Node, pass *analysis.Pass) bool {
+	fexpr := path[0]
+	for _, node := range path[1:] {
+		if _, ok := node.(*ast.ParenExpr); ok {
+			continue
+		}
+		parent, ok := node.(*ast.CallExpr)
+		if !ok || len(parent.Args) == 0 || astutil.Unparen(parent.Args[0]) != fexpr {
+			return false
+		}
+		selExpr, ok := parent.Fun.(*ast.SelectorExpr)
+		return ok && selExpr.Sel.Name == "NewContext" &&
+			(isKlog(selExpr.X, pass) || isPackage(selExpr.X, "github.com/go-logr/logr", pass))
+	}
+	return false
+}
+
+// inGoroutine checks whether the path is inside a function literal which
+// gets started with a go statement and the variable is defined outside of
+// it.
+func inGoroutine(path []ast.Node, variable *types.Var) bool {
+	for i, node := range path {
+		funcLit, ok := node.(*ast.FuncLit)
+		if !ok || variable.Pos() >= funcLit.Pos() {
+			continue
+		}
+		if i+2 < len(path) {
+			if call, ok := path[i+1].(*ast.CallExpr); ok && call.Fun == funcLit {
+				if _, ok := path[i+2].(*ast.GoStmt); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
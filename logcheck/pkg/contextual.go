@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// contextualFixes converts klog.InfoS, klog.ErrorS, klog.V(n).InfoS and
+// klog.V(n).ErrorS into calls of the corresponding logr.Logger methods.
+//
+// If a logger variable is in scope, it gets used. Otherwise the logger is
+// retrieved from the context parameter of the enclosing function with
+// klog.FromContext at the start of that function. In that case only the
+// first call in the function gets a fix which converts all calls, because
+// adding the same variable multiple times would not compile.
+func contextualFixes(fexpr *ast.CallExpr, errorKey string, pass *analysis.Pass) []analysis.SuggestedFix {
+	if !isContextualConvertible(fexpr, pass) {
+		return nil
+	}
+	if logger := loggerInScope(pass, fexpr.Pos()); logger != "" {
+		return []analysis.SuggestedFix{{
+			Message:   "Log through " + logger,
+			TextEdits: contextualEdits(fexpr, logger, errorKey, pass),
+		}}
+	}
+
+	file := fileOf(pass, fexpr.Pos())
+	if file == nil {
+		return nil
+	}
+	path, _ := astutil.PathEnclosingInterval(file, fexpr.Pos(), fexpr.End())
+	function, body, ctx := contextFunction(path, pass)
+	if body == nil || len(body.List) == 0 || usesIdent(function, "logger") {
+		return nil
+	}
+	calls := contextualCalls(body, pass)
+	if len(calls) == 0 || calls[0] != fexpr {
+		return nil
+	}
+	klog, edits, ok := importName(pass, body.Pos(), "k8s.io/klog/v2", "klog")
+	if !ok {
+		return nil
+	}
+	first := body.List[0].Pos()
+	indent := strings.Repeat("\t", pass.Fset.Position(first).Column-1)
+	edits = append(edits, analysis.TextEdit{
+		Pos:     first,
+		End:     first,
+		NewText: []byte(fmt.Sprintf("logger := %s.FromContext(%s)\n%s", klog, ctx, indent)),
+	})
+	for _, call := range calls {
+		edits = append(edits, contextualEdits(call, "logger", errorKey, pass)...)
+	}
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Log through klog.FromContext(%s)", ctx),
+		TextEdits: edits,
+	}}
+}
+
+// isContextualConvertible checks whether the call is one of the klog calls
+// which contextualEdits can convert.
+func isContextualConvertible(fexpr *ast.CallExpr, pass *analysis.Pass) bool {
+	selExpr, ok := fexpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch selExpr.Sel.Name {
+	case "InfoS", "ErrorS":
+	default:
+		return false
+	}
+	if isPackage(selExpr.X, "k8s.io/klog/v2", pass) {
+		return true
+	}
+	vCallExpr := klogVCall(selExpr.X, pass)
+	if vCallExpr == nil {
+		return false
+	}
+	// V(n).ErrorS becomes V(n).Info with the error as value, which
+	// needs the individual parameters.
+	return selExpr.Sel.Name == "InfoS" || !fexpr.Ellipsis.IsValid() && len(fexpr.Args) >= 2
+}
+
+// klogVCall returns the call if the expression is klog.V(n).
+func klogVCall(expr ast.Expr, pass *analysis.Pass) *ast.CallExpr {
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok || len(callExpr.Args) != 1 {
+		return nil
+	}
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "V" || !isPackage(selExpr.X, "k8s.io/klog/v2", pass) {
+		return nil
+	}
+	return callExpr
+}
+
+// contextualEdits replaces klog with the logger variable in a call accepted
+// by isContextualConvertible.
+func contextualEdits(fexpr *ast.CallExpr, logger, errorKey string, pass *analysis.Pass) []analysis.TextEdit {
+	selExpr := fexpr.Fun.(*ast.SelectorExpr)
+	method := strings.TrimSuffix(selExpr.Sel.Name, "S")
+	vCallExpr := klogVCall(selExpr.X, pass)
+	if vCallExpr == nil {
+		return []analysis.TextEdit{{
+			Pos:     selExpr.Pos(),
+			End:     selExpr.End(),
+			NewText: []byte(logger + "." + method),
+		}}
+	}
+
+	edits := []analysis.TextEdit{{
+		Pos:     vCallExpr.Fun.(*ast.SelectorExpr).X.Pos(),
+		End:     vCallExpr.Fun.(*ast.SelectorExpr).X.End(),
+		NewText: []byte(logger),
+	}}
+	// logr.Logger.V expects an int, klog.V a klog.Level. Untyped
+	// constants work for both.
+	level := vCallExpr.Args[0]
+	if !isUntypedConstant(level, pass) {
+		edits = append(edits,
+			analysis.TextEdit{Pos: level.Pos(), End: level.Pos(), NewText: []byte("int(")},
+			analysis.TextEdit{Pos: level.End(), End: level.End(), NewText: []byte(")")},
+		)
+	}
+	edits = append(edits, analysis.TextEdit{
+		Pos:     selExpr.Sel.Pos(),
+		End:     selExpr.Sel.End(),
+		NewText: []byte("Info"),
+	})
+	if method == "Error" {
+		// Same as for V().Error, logr would ignore the verbosity.
+		edits = append(edits, analysis.TextEdit{
+			Pos:     fexpr.Args[0].Pos(),
+			End:     fexpr.Args[1].End(),
+			NewText: []byte(fmt.Sprintf("%s, %q, %s", formatNode(pass.Fset, fexpr.Args[1]), errorKey, formatNode(pass.Fset, fexpr.Args[0]))),
+		})
+	}
+	return edits
+}
+
+// isUntypedConstant checks for literals and untyped named constants. The type
+// recorded for constant expressions is the type that they get converted to,
+// so it cannot be used to determine this.
+func isUntypedConstant(expr ast.Expr, pass *analysis.Pass) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		if constant, ok := pass.TypesInfo.Uses[expr].(*types.Const); ok {
+			basic, ok := constant.Type().(*types.Basic)
+			return ok && basic.Info()&types.IsUntyped != 0
+		}
+	}
+	return false
+}
+
+// loggerInScope returns the name of a local logr.Logger variable which is
+// visible at the given position, with "logger" taking precedence over other
+// names. Package variables are ignored because using them is not contextual
+// logging.
+func loggerInScope(pass *analysis.Pass, pos token.Pos) string {
+	if variable := variableInScope(pass, pos, "github.com/go-logr/logr", "Logger", "logger"); variable != nil {
+		return variable.Name()
+	}
+	return ""
+}
+
+// variableInScope returns a local variable of the named type which is
+// visible at the given position. A variable with the preferred name takes
+// precedence, otherwise the innermost one is returned.
+func variableInScope(pass *analysis.Pass, pos token.Pos, packagePath, typeName, preferred string) *types.Var {
+	innermost := pass.Pkg.Scope().Innermost(pos)
+	var found []*types.Var
+	for scope := innermost; scope != nil && scope != pass.Pkg.Scope() && scope.Parent() != pass.Pkg.Scope(); scope = scope.Parent() {
+		for _, name := range scope.Names() {
+			variable, ok := scope.Lookup(name).(*types.Var)
+			if !ok || !isNamedType(variable.Type(), packagePath, typeName) {
+				continue
+			}
+			if _, object := innermost.LookupParent(name, pos); object != variable {
+				// Declared later or shadowed.
+				continue
+			}
+			if name == preferred {
+				return variable
+			}
+			found = append(found, variable)
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+// contextFunction returns the innermost function on the path which has a
+// context parameter, its body and the name of that parameter.
+func contextFunction(path []ast.Node, pass *analysis.Pass) (ast.Node, *ast.BlockStmt, string) {
+	for _, node := range path {
+		var funcType *ast.FuncType
+		var body *ast.BlockStmt
+		switch node := node.(type) {
+		case *ast.FuncDecl:
+			funcType, body = node.Type, node.Body
+		case *ast.FuncLit:
+			funcType, body = node.Type, node.Body
+		default:
+			continue
+		}
+		if ctx := contextParam(funcType, pass); ctx != "" {
+			return node, body, ctx
+		}
+	}
+	return nil, nil, ""
+}
+
+// contextParam returns the name of the first context.Context parameter.
+func contextParam(funcType *ast.FuncType, pass *analysis.Pass) string {
+	for _, field := range funcType.Params.List {
+		if !isNamedType(pass.TypesInfo.TypeOf(field.Type), "context", "Context") {
+			continue
+		}
+		for _, name := range field.Names {
+			if name.Name != "_" {
+				return name.Name
+			}
+		}
+	}
+	return ""
+}
+
+// contextualCalls returns all calls in the body which get converted by the fix
+// that retrieves the logger from the context. Calls in function literals
+// with their own context and calls which can use a logger variable are
+// handled separately.
+func contextualCalls(body *ast.BlockStmt, pass *analysis.Pass) []*ast.CallExpr {
+	var calls []*ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return contextParam(n.Type, pass) == ""
+		case *ast.CallExpr:
+			if isContextualConvertible(n, pass) && loggerInScope(pass, n.Pos()) == "" {
+				calls = append(calls, n)
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// usesIdent checks whether the name is used anywhere inside the node.
+func usesIdent(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
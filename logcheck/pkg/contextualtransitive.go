@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// globalKlogCalls is a fact that is inferred for functions and methods which
+// call klog functions that are not allowed in contextual code, either
+// directly or through other functions. Chain lists the calls which lead to
+// the klog function, starting with the function itself.
+type globalKlogCalls struct {
+	Chain []string
+}
+
+func (g *globalKlogCalls) AFact() {}
+
+func (g *globalKlogCalls) String() string {
+	return "calls " + strings.Join(g.Chain, " -> ")
+}
+
+// inferGlobalKlogCalls exports a globalKlogCalls fact for all functions in
+// the package whose body calls klog functions that are not allowed in
+// contextual code or functions which have the fact. Calls in the same package
+// are handled by repeating the search until no new function is found. The
+// chains for the functions of the package are also returned.
+//
+// Both the contextual-transitive and the goroutine check depend on this.
+func inferGlobalKlogCalls(pass *analysis.Pass, c *Config) map[*types.Func][]string {
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		filename := fileKey(pass, file.Pos())
+		if !c.isEnabled(contextualTransitiveCheck, filename) && !c.isEnabled(goroutineCheck, filename) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
+				decls = append(decls, decl)
+			}
+		}
+	}
+
+	chains := map[*types.Func][]string{}
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range decls {
+			function, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok || chains[function] != nil {
+				continue
+			}
+			if chain := globalKlogChain(decl.Body, chains, pass); chain != nil {
+				chains[function] = append([]string{functionName(function)}, chain...)
+				changed = true
+			}
+		}
+	}
+
+	for _, decl := range decls {
+		function, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok || chains[function] == nil {
+			continue
+		}
+		pass.ExportObjectFact(function, &globalKlogCalls{Chain: chains[function]})
+	}
+	return chains
+}
+
+// globalKlogChain returns the chain of calls for the first call inside the
+// node which leads to a klog function that is not allowed in contextual code.
+func globalKlogChain(node ast.Node, chains map[*types.Func][]string, pass *analysis.Pass) []string {
+	var chain []string
+	ast.Inspect(node, func(n ast.Node) bool {
+		if chain != nil {
+			return false
+		}
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && isKlog(selExpr.X, pass) && !isContextualCall(selExpr.Sel.Name) {
+			if isKlogVerbose(selExpr.X, pass) {
+				chain = []string{"klog.V()." + selExpr.Sel.Name}
+			} else {
+				chain = []string{"klog." + selExpr.Sel.Name}
+			}
+			return false
+		}
+		if function, ok := calledObject(callExpr, pass).(*types.Func); ok {
+			chain = globalKlogChainOf(function, chains, pass)
+		}
+		return chain == nil
+	})
+	return chain
+}
+
+// globalKlogChainOf returns the chain of calls which starts with the function
+// and leads to a klog function that is not allowed in contextual code, if
+// there is one. Functions in the current package are looked up in chains,
+// those from other packages in the globalKlogCalls facts.
+func globalKlogChainOf(function *types.Func, chains map[*types.Func][]string, pass *analysis.Pass) []string {
+	if function.Pkg() == pass.Pkg {
+		return chains[function]
+	}
+	var fact globalKlogCalls
+	if pass.ImportObjectFact(function, &fact) {
+		return fact.Chain
+	}
+	return nil
+}
+
+// functionName returns the name of a function or method with the package
+// name as prefix, for example "klog.InfoS" or "klog.Verbose.InfoS".
+func functionName(function *types.Func) string {
+	name := function.Name()
+	if recv := function.Type().(*types.Signature).Recv(); recv != nil {
+		if recvName := receiverName(recv.Type()); recvName != "" {
+			name = recvName + "." + name
+		}
+	}
+	if function.Pkg() != nil {
+		name = function.Pkg().Name() + "." + name
+	}
+	return name
+}
+
+// checkForGlobalKlogCalls reports calls of functions which have the
+// globalKlogCalls fact.
+func checkForGlobalKlogCalls(object types.Object, pos token.Pos, pass *analysis.Pass) {
+	function, ok := object.(*types.Func)
+	if !ok {
+		return
+	}
+	var fact globalKlogCalls
+	if !pass.ImportObjectFact(function, &fact) {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     pos,
+		Message: fmt.Sprintf("function %q calls klog functions which are not allowed in contextual code (%s), convert to contextual logging", function.Name(), strings.Join(fact.Chain, " -> ")),
+	})
+}
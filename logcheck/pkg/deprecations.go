@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// deprecatedAPI identifies a function, method or function variable.
+type deprecatedAPI struct {
+	// pkg is the import path of the package which defines the API.
+	pkg string
+	// recv is the name of the receiver type for methods, empty otherwise.
+	recv string
+	// name is the name of the function, method or variable.
+	name string
+}
+
+// deprecation describes what replaces a deprecated API.
+type deprecation struct {
+	// replacementPkg is the import path of the package which defines the
+	// replacement function. Empty when the replacement is defined in the
+	// same package or is a method of the same type.
+	replacementPkg string
+	// replacement is the name of the replacement function or method.
+	replacement string
+	// args lists the arguments of the replacement call. "$0", "$1", ...
+	// stand for the argument with that index in the deprecated call,
+	// "$1..." for that argument and all which follow it. Other entries
+	// are Go expressions which get inserted as they are, with "$pkg"
+	// replaced by the name of the replacement package. Nil means that all
+	// arguments are passed unchanged.
+	args []string
+	// since is the module and release which deprecated the API.
+	since string
+}
+
+// deprecatedAPIs lists APIs which get reported by the deprecations check.
+// New entries should come with a replacement that can be applied
+// automatically.
+var deprecatedAPIs = map[deprecatedAPI]deprecation{
+	{pkg: "k8s.io/klog/v2", name: "KObjs"}: {
+		replacement: "KObjSlice",
+		since:       "k8s.io/klog/v2 v2.70.0",
+	},
+	{pkg: "k8s.io/klog/v2", recv: "Verbose", name: "Error"}: {
+		replacement: "ErrorS",
+		since:       "k8s.io/klog/v2 v2.3.0",
+	},
+	{pkg: "k8s.io/klog/v2/klogr", name: "New"}: {
+		replacementPkg: "k8s.io/klog/v2/textlogger",
+		replacement:    "NewLogger",
+		args:           []string{"$pkg.NewConfig()"},
+		since:          "k8s.io/klog/v2 v2.110.1",
+	},
+	{pkg: "github.com/go-logr/logr/slogr", name: "NewLogr"}: {
+		replacementPkg: "github.com/go-logr/logr",
+		replacement:    "FromSlogHandler",
+		since:          "github.com/go-logr/logr v1.4.1",
+	},
+	{pkg: "github.com/go-logr/logr/slogr", name: "NewSlogHandler"}: {
+		replacementPkg: "github.com/go-logr/logr",
+		replacement:    "ToSlogHandler",
+		since:          "github.com/go-logr/logr v1.4.1",
+	},
+	{pkg: "github.com/go-logr/logr/slogr", name: "ToSlogHandler"}: {
+		replacementPkg: "github.com/go-logr/logr",
+		replacement:    "ToSlogHandler",
+		since:          "github.com/go-logr/logr v1.4.1",
+	},
+	{pkg: "github.com/go-logr/logr/testing", name: "NewTestLogger"}: {
+		replacementPkg: "github.com/go-logr/logr/testr",
+		replacement:    "New",
+		since:          "github.com/go-logr/logr v1.2.0",
+	},
+	{pkg: "github.com/go-logr/logr/testing", name: "NewTestLoggerWithOptions"}: {
+		replacementPkg: "github.com/go-logr/logr/testr",
+		replacement:    "NewWithOptions",
+		since:          "github.com/go-logr/logr v1.2.0",
+	},
+}
+
+// checkForDeprecation reports calls of deprecated APIs and suggests calling
+// the replacement instead.
+func checkForDeprecation(fexpr *ast.CallExpr, selExpr *ast.SelectorExpr, pass *analysis.Pass) {
+	object := pass.TypesInfo.ObjectOf(selExpr.Sel)
+	if object == nil || object.Pkg() == nil {
+		return
+	}
+	api := deprecatedAPI{pkg: object.Pkg().Path(), name: object.Name()}
+	if function, ok := object.(*types.Func); ok {
+		if recv := function.Type().(*types.Signature).Recv(); recv != nil {
+			api.recv = receiverName(recv.Type())
+		}
+	}
+	d, ok := deprecatedAPIs[api]
+	if !ok {
+		return
+	}
+
+	replacement := d.replacement
+	if d.replacementPkg != "" {
+		replacement = path.Base(d.replacementPkg) + "." + replacement
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            fexpr.Fun.Pos(),
+		Message:        fmt.Sprintf(`Detected usage of deprecated helper "%s". Please switch to "%s" instead. It was deprecated in %s.`, api.name, replacement, d.since),
+		SuggestedFixes: deprecationFixes(fexpr, selExpr, d, pass),
+	})
+}
+
+func deprecationFixes(fexpr *ast.CallExpr, selExpr *ast.SelectorExpr, d deprecation, pass *analysis.Pass) []analysis.SuggestedFix {
+	var edits []analysis.TextEdit
+	var name string
+	if d.replacementPkg == "" {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     selExpr.Sel.Pos(),
+			End:     selExpr.Sel.End(),
+			NewText: []byte(d.replacement),
+		})
+	} else {
+		var importEdits []analysis.TextEdit
+		var ok bool
+		name, importEdits, ok = importName(pass, fexpr.Pos(), d.replacementPkg, path.Base(d.replacementPkg))
+		if !ok {
+			return nil
+		}
+		edits = append(edits, importEdits...)
+		if ident, ok := selExpr.X.(*ast.Ident); ok {
+			edits = append(edits, removeImportIfUnused(pass, ident)...)
+		}
+		edits = append(edits, analysis.TextEdit{
+			Pos:     selExpr.Pos(),
+			End:     selExpr.End(),
+			NewText: []byte(name + "." + d.replacement),
+		})
+	}
+
+	if d.args != nil {
+		args, ok := mapArgs(fexpr, d.args, name, pass)
+		if !ok {
+			return nil
+		}
+		edits = append(edits, analysis.TextEdit{
+			Pos:     fexpr.Lparen + 1,
+			End:     fexpr.Rparen,
+			NewText: []byte(strings.Join(args, ", ")),
+		})
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   "Switch to " + d.replacement,
+		TextEdits: edits,
+	}}
+}
+
+// mapArgs returns the source code for the arguments of the replacement call,
+// as described for deprecation.args.
+func mapArgs(fexpr *ast.CallExpr, mapping []string, pkgName string, pass *analysis.Pass) ([]string, bool) {
+	if fexpr.Ellipsis.IsValid() {
+		return nil, false
+	}
+	var args []string
+	for _, arg := range mapping {
+		if !strings.HasPrefix(arg, "$") || strings.HasPrefix(arg, "$pkg") {
+			if pkgName == "" && strings.Contains(arg, "$pkg") {
+				return nil, false
+			}
+			args = append(args, strings.ReplaceAll(arg, "$pkg", pkgName))
+			continue
+		}
+		index, rest := strings.CutSuffix(arg[1:], "...")
+		i, err := strconv.Atoi(index)
+		if err != nil || i >= len(fexpr.Args) && !rest {
+			return nil, false
+		}
+		end := i + 1
+		if rest {
+			end = len(fexpr.Args)
+		}
+		for _, expr := range fexpr.Args[min(i, end):end] {
+			args = append(args, formatNode(pass.Fset, expr))
+		}
+	}
+	return args, true
+}
+
+// receiverName returns the name of the receiver type, without pointer.
+func receiverName(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return ""
+}
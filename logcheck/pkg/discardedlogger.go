@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// checkForDiscardedLogger reports expression statements which create a
+// logr.Logger or klog.Verbose and then throw it away. Because those are value
+// types, something like logger.WithValues("pod", klog.KObj(pod)) on its own has
+// no effect.
+func checkForDiscardedLogger(stmt *ast.ExprStmt, pass *analysis.Pass) {
+	callExpr, ok := astutil.Unparen(stmt.X).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	t := pass.TypesInfo.TypeOf(callExpr)
+	if t == nil || !(isNamedType(t, "github.com/go-logr/logr", "Logger") || isNamedType(t, "k8s.io/klog/v2", "Verbose")) {
+		return
+	}
+	object := calledObject(callExpr, pass)
+	if object == nil || object.Pkg() == nil {
+		return
+	}
+	switch object.Pkg().Path() {
+	case "github.com/go-logr/logr", "k8s.io/klog/v2":
+	default:
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:            callExpr.Pos(),
+		Message:        fmt.Sprintf("The result of %s is not used. It is a value, so the call has no effect.", formatNode(pass.Fset, callExpr.Fun)),
+		SuggestedFixes: discardedLoggerFixes(callExpr, t, pass),
+	})
+}
+
+// discardedLoggerFixes assigns the result to the logger variable which was
+// passed in, as in logger = logger.WithValues(...) or
+// logger = klog.LoggerWithName(logger, ...).
+func discardedLoggerFixes(callExpr *ast.CallExpr, t types.Type, pass *analysis.Pass) []analysis.SuggestedFix {
+	var logger ast.Expr
+	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && isGoLogger(selExpr.X, pass) {
+		switch selExpr.Sel.Name {
+		case "WithValues", "WithName", "WithCallDepth":
+			logger = selExpr.X
+		}
+	}
+	if logger == nil && len(callExpr.Args) > 0 && isGoLogger(callExpr.Args[0], pass) {
+		logger = callExpr.Args[0]
+	}
+	ident, ok := logger.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	variable, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || !types.Identical(variable.Type(), t) {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Assign the result to %s", ident.Name),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     callExpr.Pos(),
+			End:     callExpr.Pos(),
+			NewText: []byte(ident.Name + " = "),
+		}},
+	}}
+}
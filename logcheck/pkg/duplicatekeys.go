@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkForDuplicateKeys reports keys which get passed more than once in the
+// same call and keys which were already added to the logger with
+// WithValues or klog.LoggerWithValues. Loggers are tracked through local
+// variables in the order in which the function assigns them, without
+// considering the control flow.
+func checkForDuplicateKeys(body *ast.BlockStmt, pass *analysis.Pass) {
+	loggerKeys := map[types.Object]map[string]bool{}
+
+	// keysOf returns the keys which were added to the logger.
+	var keysOf func(expr ast.Expr) map[string]bool
+	keysOf = func(expr ast.Expr) map[string]bool {
+		switch expr := expr.(type) {
+		case *ast.ParenExpr:
+			return keysOf(expr.X)
+		case *ast.Ident:
+			return loggerKeys[pass.TypesInfo.ObjectOf(expr)]
+		case *ast.CallExpr:
+			base, keyValues := derivedLogger(expr, pass)
+			if base == nil {
+				return nil
+			}
+			keys := map[string]bool{}
+			for key := range keysOf(base) {
+				keys[key] = true
+			}
+			for i := 0; i < len(keyValues); i += 2 {
+				if key, ok := constantKey(keyValues[i], pass); ok {
+					keys[key] = true
+				}
+			}
+			return keys
+		}
+		return nil
+	}
+
+	checkCall := func(callExpr *ast.CallExpr) {
+		keyValues, ok := keyValueArgs(callExpr, pass)
+		if !ok || callExpr.Ellipsis.IsValid() {
+			return
+		}
+		var existingKeys map[string]bool
+		if base := loggerOfCall(callExpr, pass); base != nil {
+			existingKeys = keysOf(base)
+		}
+		seen := map[string]bool{}
+		for i := 0; i < len(keyValues); i += 2 {
+			key, ok := constantKey(keyValues[i], pass)
+			if !ok {
+				continue
+			}
+			switch {
+			case seen[key]:
+				pass.Report(analysis.Diagnostic{
+					Pos:     keyValues[i].Pos(),
+					Message: fmt.Sprintf("Key %q is passed more than once.", key),
+				})
+			case existingKeys[key]:
+				pass.Report(analysis.Diagnostic{
+					Pos:     keyValues[i].Pos(),
+					Message: fmt.Sprintf("Key %q was already added to the logger with WithValues.", key),
+				})
+			}
+			seen[key] = true
+		}
+	}
+
+	// Assignments update the known keys after visiting their right-hand
+	// side, so logger = logger.WithValues(...) gets checked against the
+	// previous value.
+	assign := func(lhs []ast.Expr, rhs []ast.Expr) {
+		if len(lhs) != len(rhs) {
+			return
+		}
+		keys := make([]map[string]bool, len(rhs))
+		for i, expr := range rhs {
+			keys[i] = keysOf(expr)
+		}
+		for i, expr := range lhs {
+			if ident, ok := expr.(*ast.Ident); ok {
+				if object := pass.TypesInfo.ObjectOf(ident); object != nil {
+					loggerKeys[object] = keys[i]
+				}
+			}
+		}
+	}
+	var stack []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			switch n := stack[len(stack)-1].(type) {
+			case *ast.AssignStmt:
+				assign(n.Lhs, n.Rhs)
+			case *ast.ValueSpec:
+				lhs := make([]ast.Expr, 0, len(n.Names))
+				for _, name := range n.Names {
+					lhs = append(lhs, name)
+				}
+				assign(lhs, n.Values)
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, n)
+		if callExpr, ok := n.(*ast.CallExpr); ok {
+			checkCall(callExpr)
+		}
+		return true
+	})
+}
+
+// checkForPackageLevelDuplicateKeys checks function literals outside of
+// functions, for example in the initialization of a global variable. Those
+// inside functions get checked together with the function.
+func checkForPackageLevelDuplicateKeys(file *ast.File, pass *analysis.Pass) {
+	for _, decl := range file.Decls {
+		if _, ok := decl.(*ast.GenDecl); !ok {
+			continue
+		}
+		ast.Inspect(decl, func(n ast.Node) bool {
+			if funcLit, ok := n.(*ast.FuncLit); ok {
+				checkForDuplicateKeys(funcLit.Body, pass)
+				return false
+			}
+			return true
+		})
+	}
+}
+
+// derivedLogger checks whether the call creates a new logger from another
+// one. It returns the expression for the other logger and the key/value
+// pairs which get added, if any.
+func derivedLogger(callExpr *ast.CallExpr, pass *analysis.Pass) (ast.Expr, []ast.Expr) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	switch {
+	case isGoLogger(selExpr.X, pass):
+		switch selExpr.Sel.Name {
+		case "WithValues":
+			return selExpr.X, callExpr.Args
+		case "WithName", "V":
+			return selExpr.X, nil
+		}
+	case isPackage(selExpr.X, "k8s.io/klog/v2", pass) && len(callExpr.Args) > 0:
+		switch selExpr.Sel.Name {
+		case "LoggerWithValues":
+			return callExpr.Args[0], callExpr.Args[1:]
+		case "LoggerWithName":
+			return callExpr.Args[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// loggerOfCall returns the logger which gets used by a call which has
+// key/value parameters. Global klog functions have none.
+func loggerOfCall(callExpr *ast.CallExpr, pass *analysis.Pass) ast.Expr {
+	selExpr := callExpr.Fun.(*ast.SelectorExpr)
+	if isGoLogger(selExpr.X, pass) {
+		return selExpr.X
+	}
+	if selExpr.Sel.Name == "LoggerWithValues" && len(callExpr.Args) > 0 {
+		return callExpr.Args[0]
+	}
+	return nil
+}
+
+// constantKey returns the value of a key which is a constant string.
+func constantKey(expr ast.Expr, pass *analysis.Pass) (string, bool) {
+	typeAndValue, ok := pass.TypesInfo.Types[expr]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(typeAndValue.Value), true
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// expensiveFunctions are functions whose result is costly to compute when
+// the log call does not need it.
+var expensiveFunctions = map[string]map[string]bool{
+	"fmt":           {"Sprint": true, "Sprintf": true, "Sprintln": true},
+	"encoding/json": {"Marshal": true, "MarshalIndent": true},
+}
+
+// checkForExpensiveArguments reports arguments of V(n).Info and similar calls
+// which are costly to evaluate, like fmt.Sprintf or String calls. Go
+// evaluates them even when the verbosity is disabled. This is okay for low
+// verbosity levels, which are normally enabled, and when the call is guarded
+// by Enabled() for the same or a higher verbosity. Only Info calls are
+// checked because Error calls of logr.Logger log regardless of the
+// verbosity.
+func checkForExpensiveArguments(fexpr *ast.CallExpr, pass *analysis.Pass, minVerbosity int) {
+	selExpr, ok := fexpr.Fun.(*ast.SelectorExpr)
+	if !ok || !strings.HasPrefix(selExpr.Sel.Name, "Info") {
+		return
+	}
+	verbosity, ok := verbosityOf(selExpr.X, pass)
+	if !ok || verbosity.level < int64(minVerbosity) || isGuardedByEnabled(fexpr, verbosity, pass) {
+		return
+	}
+	level := verbosity.level
+
+	for _, arg := range fexpr.Args {
+		ast.Inspect(arg, func(n ast.Node) bool {
+			what := expensiveExpression(n, pass)
+			if what == "" {
+				// Function literals are not evaluated.
+				_, isFuncLit := n.(*ast.FuncLit)
+				return !isFuncLit
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     n.Pos(),
+				Message: fmt.Sprintf("%s is evaluated even when V(%d) is disabled. Check Enabled() first.", what, level),
+			})
+			return false
+		})
+	}
+}
+
+// expensiveExpression returns a description of the node if it is costly to
+// evaluate.
+func expensiveExpression(n ast.Node, pass *analysis.Pass) string {
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		if function, ok := calledObject(n, pass).(*types.Func); ok {
+			if pkg := function.Pkg(); pkg != nil && expensiveFunctions[pkg.Path()][function.Name()] {
+				return fmt.Sprintf("The %s.%s call", pkg.Name(), function.Name())
+			}
+			if function.Name() == "String" && len(n.Args) == 0 && function.Type().(*types.Signature).Recv() != nil {
+				return "The String call"
+			}
+		}
+	case *ast.UnaryExpr:
+		if _, ok := n.X.(*ast.CompositeLit); ok && n.Op == token.AND {
+			return "The allocation"
+		}
+	case *ast.CompositeLit:
+		switch pass.TypesInfo.TypeOf(n).Underlying().(type) {
+		case *types.Slice, *types.Map:
+			return "The allocation"
+		}
+	}
+	return ""
+}
+
+// verbosity identifies the result of a V call by the logger, or klog, on
+// which V gets called and the level.
+type verbosity struct {
+	base  string
+	level int64
+}
+
+// verbosityOf determines the verbosity for a V(n) call with a constant level
+// or a variable which gets initialized with such a call and never changes,
+// like `v := logger.V(5)`.
+func verbosityOf(expr ast.Expr, pass *analysis.Pass) (verbosity, bool) {
+	if ident, ok := expr.(*ast.Ident); ok {
+		variable, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok {
+			return verbosity{}, false
+		}
+		values := assignedValues(variable, pass)
+		if len(values) != 1 {
+			return verbosity{}, false
+		}
+		expr = values[0]
+	}
+	vCallExpr, ok := astutil.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(vCallExpr.Args) != 1 {
+		return verbosity{}, false
+	}
+	vSelExpr, ok := vCallExpr.Fun.(*ast.SelectorExpr)
+	if !ok || vSelExpr.Sel.Name != "V" || !isKlogVerbose(vCallExpr, pass) && !isGoLogger(vCallExpr, pass) {
+		return verbosity{}, false
+	}
+	typeAndValue, ok := pass.TypesInfo.Types[vCallExpr.Args[0]]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.Int {
+		return verbosity{}, false
+	}
+	level, ok := constant.Int64Val(typeAndValue.Value)
+	if !ok {
+		return verbosity{}, false
+	}
+	return verbosity{base: formatNode(pass.Fset, vSelExpr.X), level: level}, true
+}
+
+// isGuardedByEnabled checks whether the call is inside an if statement which
+// checks Enabled() for the same or a higher verbosity or whether it comes
+// after an if statement which returns when Enabled() is false.
+func isGuardedByEnabled(fexpr *ast.CallExpr, v verbosity, pass *analysis.Pass) bool {
+	file := fileOf(pass, fexpr.Pos())
+	if file == nil {
+		return false
+	}
+	path, _ := astutil.PathEnclosingInterval(file, fexpr.Pos(), fexpr.End())
+	for i, node := range path {
+		switch node := node.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			if i > 0 && path[i-1] == node.Body && callsEnabled(node.Cond, v, pass) {
+				return true
+			}
+		case *ast.BlockStmt:
+			for _, stmt := range node.List {
+				if stmt.Pos() >= fexpr.Pos() {
+					break
+				}
+				if ifStmt, ok := stmt.(*ast.IfStmt); ok && returnsIfNotEnabled(ifStmt, v, pass) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// returnsIfNotEnabled checks for `if !logger.V(5).Enabled() { return }`.
+func returnsIfNotEnabled(ifStmt *ast.IfStmt, v verbosity, pass *analysis.Pass) bool {
+	cond := astutil.Unparen(ifStmt.Cond)
+	unaryExpr, ok := cond.(*ast.UnaryExpr)
+	if !ok || unaryExpr.Op != token.NOT || !callsEnabled(unaryExpr.X, v, pass) || len(ifStmt.Body.List) == 0 {
+		return false
+	}
+	switch ifStmt.Body.List[len(ifStmt.Body.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	}
+	return false
+}
+
+// callsEnabled checks whether the expression contains a call of Enabled for
+// klog.Verbose or logr.Logger with the given verbosity or a higher one. If
+// that is enabled, then the given one is, too.
+func callsEnabled(expr ast.Expr, v verbosity, pass *analysis.Pass) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if callExpr, ok := n.(*ast.CallExpr); ok {
+			if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "Enabled" {
+				if enabled, ok := verbosityOf(selExpr.X, pass); ok && enabled.base == v.base && enabled.level >= v.level {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"go/version"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// formatNode returns the source code for the node.
+func formatNode(fset *token.FileSet, node ast.Node) string {
+	var buffer bytes.Buffer
+	if err := format.Node(&buffer, fset, node); err != nil {
+		return ""
+	}
+	return buffer.String()
+}
+
+// fileOf returns the file which contains the position.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// goVersionAtLeast checks whether the file may use features of the Go
+// version, according to its //go:build constraint or the version of the
+// package. An unknown version does not restrict anything, as in the type
+// checker.
+func goVersionAtLeast(pass *analysis.Pass, file *ast.File, goVersion string) bool {
+	fileVersion := pass.TypesInfo.FileVersions[file]
+	if fileVersion == "" {
+		fileVersion = pass.Pkg.GoVersion()
+	}
+	return fileVersion == "" || version.Compare(fileVersion, goVersion) >= 0
+}
+
+// importName determines how code at the given position can refer to the
+// package with the given import path. If the file already imports it, the
+// name of that import is used, which may be an alias like in
+// `klog "k8s.io/klog/v2"`. Otherwise the package gets imported under its
+// default name with the returned edit. This is not possible when that name
+// is already used for something else, in which case ok is false.
+func importName(pass *analysis.Pass, pos token.Pos, packagePath, defaultName string) (name string, edits []analysis.TextEdit, ok bool) {
+	file := fileOf(pass, pos)
+	if file == nil {
+		return "", nil, false
+	}
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err != nil || path != packagePath {
+			continue
+		}
+		if pkgName := pass.TypesInfo.PkgNameOf(spec); pkgName != nil && pkgName.Name() != "_" && pkgName.Name() != "." {
+			return pkgName.Name(), nil, true
+		}
+	}
+
+	if scope := pass.Pkg.Scope().Innermost(pos); scope != nil {
+		if _, obj := scope.LookupParent(defaultName, pos); obj != nil {
+			return "", nil, false
+		}
+	}
+
+	// Add to the last import declaration, if there is one.
+	var lastImport *ast.GenDecl
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			lastImport = genDecl
+		}
+	}
+	var edit analysis.TextEdit
+	switch {
+	case lastImport == nil:
+		edit = analysis.TextEdit{
+			Pos:     file.Name.End(),
+			End:     file.Name.End(),
+			NewText: []byte(fmt.Sprintf("\n\nimport %q", packagePath)),
+		}
+	case lastImport.Lparen.IsValid():
+		edit = analysis.TextEdit{
+			Pos:     lastImport.Rparen,
+			End:     lastImport.Rparen,
+			NewText: []byte(fmt.Sprintf("\t%q\n", packagePath)),
+		}
+	default:
+		edit = analysis.TextEdit{
+			Pos:     lastImport.End(),
+			End:     lastImport.End(),
+			NewText: []byte(fmt.Sprintf("\nimport %q", packagePath)),
+		}
+	}
+	return defaultName, []analysis.TextEdit{edit}, true
+}
+
+// removeImportIfUnused removes the import of the package referenced by ident
+// if ident is the only reference to it in the file. This avoids compile
+// errors after a fix which replaces that reference. If the import is the
+// only one in its declaration, the entire declaration gets removed.
+func removeImportIfUnused(pass *analysis.Pass, ident *ast.Ident) []analysis.TextEdit {
+	pkgName, ok := pass.TypesInfo.Uses[ident].(*types.PkgName)
+	if !ok {
+		return nil
+	}
+	file := fileOf(pass, ident.Pos())
+	if file == nil {
+		return nil
+	}
+	uses := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[id] == pkgName {
+			uses++
+		}
+		return true
+	})
+	if uses != 1 {
+		return nil
+	}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if pass.TypesInfo.PkgNameOf(spec.(*ast.ImportSpec)) != pkgName {
+				continue
+			}
+			if len(genDecl.Specs) == 1 && !genDecl.Lparen.IsValid() {
+				// Only the declaration itself gets removed, without
+				// the line break after it. An import which gets
+				// added by the same fix is inserted there. In a
+				// declaration with parentheses, only the line gets
+				// removed because a new import gets added inside
+				// them.
+				return []analysis.TextEdit{{
+					Pos: genDecl.Pos(),
+					End: genDecl.End(),
+				}}
+			}
+			// Remove the entire line.
+			tokFile := pass.Fset.File(spec.Pos())
+			line := tokFile.Line(spec.Pos())
+			return []analysis.TextEdit{{
+				Pos: tokFile.LineStart(line),
+				End: tokFile.LineStart(line + 1),
+			}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// checkForGoroutineLogger reports goroutines which log through global klog
+// functions or klog.Background although the function which starts them has
+// a context or logger parameter that could have been passed to them. Calls
+// of functions which do that are found with the chains from
+// inferGlobalKlogCalls for the current package and the globalKlogCalls facts
+// for other packages.
+func checkForGoroutineLogger(stmt *ast.GoStmt, chains map[*types.Func][]string, pass *analysis.Pass) {
+	path, _ := astutil.PathEnclosingInterval(fileOf(pass, stmt.Pos()), stmt.Pos(), stmt.End())
+	variable := parameterInScope(path, variableInScope(pass, stmt.Pos(), "context", "Context", "ctx"))
+	if variable == nil {
+		variable = parameterInScope(path, variableInScope(pass, stmt.Pos(), "github.com/go-logr/logr", "Logger", "logger"))
+	}
+	if variable == nil {
+		return
+	}
+
+	if funcLit, ok := astutil.Unparen(stmt.Call.Fun).(*ast.FuncLit); ok {
+		ast.Inspect(funcLit.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.GoStmt:
+				// Gets checked separately.
+				return false
+			case *ast.CallExpr:
+				if name := globalKlogCall(n, pass); name != "" {
+					pass.Report(analysis.Diagnostic{
+						Pos:     n.Pos(),
+						Message: fmt.Sprintf("%s in a goroutine ignores the logger in %q. Pass it into the goroutine and log through it.", name, variable.Name()),
+					})
+					return true
+				}
+				function, ok := calledObject(n, pass).(*types.Func)
+				if !ok || acceptsLogger(function) {
+					return true
+				}
+				if chain := globalKlogChainOf(function, chains, pass); chain != nil {
+					pass.Report(analysis.Diagnostic{
+						Pos:     n.Pos(),
+						Message: fmt.Sprintf("%s in a goroutine logs through %s and ignores the logger in %q. Pass it into the goroutine and log through it.", function.Name(), strings.Join(chain[1:], " -> "), variable.Name()),
+					})
+				}
+			}
+			return true
+		})
+		return
+	}
+
+	function, ok := calledObject(stmt.Call, pass).(*types.Func)
+	if !ok || acceptsLogger(function) {
+		return
+	}
+	chain := globalKlogChainOf(function, chains, pass)
+	if chain == nil {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:     stmt.Call.Pos(),
+		Message: fmt.Sprintf("The goroutine %s logs through %s and ignores the logger in %q. Pass it to the function.", function.Name(), strings.Join(chain[1:], " -> "), variable.Name()),
+	})
+}
+
+// acceptsLogger checks whether the function has a context or logger
+// parameter, in which case it can get the logger from its caller.
+func acceptsLogger(function *types.Func) bool {
+	signature := function.Type().(*types.Signature)
+	for i := 0; i < signature.Params().Len(); i++ {
+		t := signature.Params().At(i).Type()
+		if isNamedType(t, "context", "Context") || isNamedType(t, "github.com/go-logr/logr", "Logger") {
+			return true
+		}
+	}
+	return false
+}
+
+// globalKlogCall returns the name of the klog function if the call is one
+// which is not allowed in contextual code or klog.Background.
+func globalKlogCall(callExpr *ast.CallExpr, pass *analysis.Pass) string {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || !isKlog(selExpr.X, pass) || selExpr.Sel.Name == "V" {
+		// klog.V gets reported as part of the call chained to it.
+		return ""
+	}
+	switch {
+	case isKlogVerbose(selExpr.X, pass) && !isContextualCall(selExpr.Sel.Name):
+		return "klog.V()." + selExpr.Sel.Name
+	case selExpr.Sel.Name == "Background" || !isContextualCall(selExpr.Sel.Name):
+		return "klog." + selExpr.Sel.Name
+	}
+	return ""
+}
+
+// declOfFunction returns the declaration of a function in the current
+// package.
+func declOfFunction(function *types.Func, pass *analysis.Pass) *ast.FuncDecl {
+	if function.Pkg() != pass.Pkg {
+		return nil
+	}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && pass.TypesInfo.Defs[decl.Name] == function {
+				return decl
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// keyInventory is a package fact which records for each key the types of the
+// values that were logged with it in the package. It is only collected for
+// files where the key-consistency check is enabled.
+type keyInventory struct {
+	Uses map[string][]keyUse
+}
+
+// keyUse describes one type of value that was logged with a key.
+type keyUse struct {
+	// Type is the full name of the type.
+	Type string
+	// Class groups types which are considered related, like all integer
+	// types. Interfaces have an empty class and are related to everything.
+	Class string
+	// Object is true for structs from outside the standard library
+	// (like *v1.Pod), which should always be logged with the same key.
+	Object bool
+	// Position is where the key was first used with this type.
+	Position string
+}
+
+func (k *keyInventory) AFact() {}
+
+func (k *keyInventory) String() string {
+	keys := make([]string, 0, len(k.Uses))
+	for key := range k.Uses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return "key inventory: " + strings.Join(keys, ", ")
+}
+
+// checkForKeyConsistency compares the keys and value types in the current
+// package against each other and against those of all dependencies. It
+// reports structs from outside the standard library which get logged with
+// different keys and keys which are used for unrelated types. The keys and
+// types of the package get exported as keyInventory fact.
+func checkForKeyConsistency(pass *analysis.Pass, c *Config) {
+	var dependencies []*keyInventory
+	for _, fact := range pass.AllPackageFacts() {
+		if inventory, ok := fact.Fact.(*keyInventory); ok && fact.Package != pass.Pkg {
+			dependencies = append(dependencies, inventory)
+		}
+	}
+
+	inventory := &keyInventory{Uses: map[string][]keyUse{}}
+	for _, file := range pass.Files {
+		filename := fileKey(pass, file.Pos())
+		if !c.isEnabled(keyConsistencyCheck, filename) {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			callExpr, ok := n.(*ast.CallExpr)
+			if !ok || callExpr.Ellipsis.IsValid() {
+				return true
+			}
+			keyValues, ok := keyValueArgs(callExpr, pass)
+			if !ok {
+				return true
+			}
+			for i := 0; i+1 < len(keyValues); i += 2 {
+				key, ok := constantKey(keyValues[i], pass)
+				t := pass.TypesInfo.TypeOf(keyValues[i+1])
+				if !ok || t == nil || isNil(keyValues[i+1], pass) {
+					continue
+				}
+				position := pass.Fset.Position(keyValues[i].Pos())
+				use := newKeyUse(t, fmt.Sprintf("%s/%s:%d", pass.Pkg.Path(), path.Base(position.Filename), position.Line))
+				if msg := keyConflict(key, use, append(dependencies, inventory)); msg != "" {
+					pass.Report(analysis.Diagnostic{
+						Pos:     keyValues[i].Pos(),
+						Message: msg,
+					})
+				}
+				inventory.add(key, use)
+			}
+			return true
+		})
+	}
+	if len(inventory.Uses) > 0 {
+		pass.ExportPackageFact(inventory)
+	}
+}
+
+func newKeyUse(t types.Type, position string) keyUse {
+	use := keyUse{
+		Type:     types.TypeString(t, nil),
+		Position: position,
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsInteger != 0:
+			use.Class = "integer"
+		case u.Info()&types.IsFloat != 0:
+			use.Class = "float"
+		case u.Info()&types.IsString != 0:
+			use.Class = "string"
+		default:
+			use.Class = types.TypeString(types.Default(u), nil)
+		}
+	case *types.Struct:
+		use.Class = types.TypeString(t, nil)
+		if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil {
+			pkgPath := named.Obj().Pkg().Path()
+			firstElement, _, _ := strings.Cut(pkgPath, "/")
+			use.Object = strings.Contains(firstElement, ".") && !isError(t) &&
+				pkgPath != "k8s.io/klog/v2" && pkgPath != "github.com/go-logr/logr"
+		}
+	default:
+		use.Class = types.TypeString(t, nil)
+	}
+	return use
+}
+
+// add records the use unless the key was already used with the same type.
+func (k *keyInventory) add(key string, use keyUse) {
+	for _, existing := range k.Uses[key] {
+		if existing.Type == use.Type {
+			return
+		}
+	}
+	k.Uses[key] = append(k.Uses[key], use)
+}
+
+// keyConflict returns a message if the inventories contain the same object
+// type with a different key or the same key with an unrelated type.
+func keyConflict(key string, use keyUse, inventories []*keyInventory) string {
+	for _, inventory := range inventories {
+		for _, existing := range inventory.Uses[key] {
+			if use.Class != "" && existing.Class != "" && use.Class != existing.Class {
+				return fmt.Sprintf("Key %q is used for %s here and for the unrelated type %s at %s.", key, use.Type, existing.Type, existing.Position)
+			}
+		}
+	}
+	if !use.Object {
+		return ""
+	}
+	for _, inventory := range inventories {
+		keys := make([]string, 0, len(inventory.Uses))
+		for otherKey := range inventory.Uses {
+			keys = append(keys, otherKey)
+		}
+		sort.Strings(keys)
+		for _, otherKey := range keys {
+			for _, existing := range inventory.Uses[otherKey] {
+				if otherKey != key && existing.Object && existing.Class == use.Class {
+					return fmt.Sprintf("Values of type %s are logged with key %q here and with key %q at %s.", use.Type, key, otherKey, existing.Position)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isNil checks for the untyped nil.
+func isNil(expr ast.Expr, pass *analysis.Pass) bool {
+	typeAndValue, ok := pass.TypesInfo.Types[expr]
+	return ok && typeAndValue.IsNil()
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// acronyms are words which are written in upper case when they are part of
+// a key, like "podUID" or "nodeIP". Acronyms which are written in upper case
+// already are recognized without being listed here.
+var acronyms = map[string]bool{
+	"ACL": true, "API": true, "CIDR": true, "CPU": true, "DNS": true,
+	"GID": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "PID": true, "QPS": true, "RPC": true, "SSH": true,
+	"TCP": true, "TLS": true, "TTL": true, "UDP": true, "UID": true,
+	"URI": true, "URL": true, "UUID": true, "VM": true, "XML": true,
+}
+
+// defaultReservedKeys are used by common JSON output formats, including the
+// one in Kubernetes.
+const defaultReservedKeys = "ts,msg,v,caller,logger,level,err"
+
+// keySet is a set of keys which can be set via a comma-separated list.
+type keySet map[string]bool
+
+func (k keySet) String() string {
+	keys := make([]string, 0, len(k))
+	for key := range k {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func (k keySet) Set(value string) error {
+	clear(k)
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			k[key] = true
+		}
+	}
+	return nil
+}
+
+// canonicalKey converts a key like "pod_name", "Pod-Name" or "pod uid" into
+// lowerCamelCase ("podName", "podUID"). It returns an empty string if the
+// key cannot be converted, for example because it contains non-ASCII
+// characters.
+func canonicalKey(key string) string {
+	var result strings.Builder
+	for i, word := range splitWords(key) {
+		upper := strings.ToUpper(word)
+		isAcronym := len(word) > 1 && word == upper
+		switch {
+		case i == 0 && isAcronym:
+			result.WriteString(word)
+		case i == 0:
+			result.WriteString(strings.ToLower(word))
+		case isAcronym || acronyms[upper]:
+			result.WriteString(upper)
+		default:
+			result.WriteString(upper[:1] + strings.ToLower(word[1:]))
+		}
+	}
+	if !keyMatchRe.MatchString(result.String()) {
+		return ""
+	}
+	return result.String()
+}
+
+// splitWords splits at all characters which are not ASCII letters or digits
+// and at the start of each upper case word in camel case, so "HTTPServer_port"
+// becomes "HTTP", "Server", "port".
+func splitWords(key string) []string {
+	var words []string
+	for _, chunk := range strings.FieldsFunc(key, func(r rune) bool { return !isAlnum(r) }) {
+		start := 0
+		for i := 1; i < len(chunk); i++ {
+			lowerToUpper := !isUpper(rune(chunk[i-1])) && isUpper(rune(chunk[i]))
+			acronymEnd := isUpper(rune(chunk[i-1])) && isUpper(rune(chunk[i])) && i+1 < len(chunk) && isLower(rune(chunk[i+1]))
+			if lowerToUpper || acronymEnd {
+				words = append(words, chunk[start:i])
+				start = i
+			}
+		}
+		words = append(words, chunk[start:])
+	}
+	return words
+}
+
+func isUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
+func isLower(r rune) bool { return r >= 'a' && r <= 'z' }
+func isAlnum(r rune) bool { return isUpper(r) || isLower(r) || (r >= '0' && r <= '9') }
+
+// keyFixes suggests replacing the key with its canonical form. The first
+// occurrence of the key in the package also gets a fix which replaces all
+// occurrences, so that related log calls continue to use the same key. Only
+// the first one gets it because otherwise applying all fixes would lead to
+// conflicting edits.
+func keyFixes(lit *ast.BasicLit, pass *analysis.Pass, index *keyIndex) []analysis.SuggestedFix {
+	key, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return nil
+	}
+	newKey := canonicalKey(key)
+	if newKey == "" {
+		return nil
+	}
+	newText := []byte(strconv.Quote(newKey))
+	fixes := []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Use %q", newKey),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     lit.Pos(),
+			End:     lit.End(),
+			NewText: newText,
+		}},
+	}}
+
+	occurrences := index.occurrences(key)
+	if len(occurrences) > 1 && occurrences[0] == lit {
+		fix := analysis.SuggestedFix{
+			Message: fmt.Sprintf("Use %q instead of %q everywhere in the package", newKey, key),
+		}
+		for _, occurrence := range occurrences {
+			fix.TextEdits = append(fix.TextEdits, analysis.TextEdit{
+				Pos:     occurrence.Pos(),
+				End:     occurrence.End(),
+				NewText: newText,
+			})
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes
+}
+
+// keyIndex maps keys to all string literals in the package which are used as
+// that key in a structured logging call. It gets built once per pass when it
+// is needed for the first time.
+type keyIndex struct {
+	pass *analysis.Pass
+	keys map[string][]*ast.BasicLit
+}
+
+func newKeyIndex(pass *analysis.Pass) *keyIndex {
+	return &keyIndex{pass: pass}
+}
+
+// occurrences returns the string literals for the key in source code order.
+func (k *keyIndex) occurrences(key string) []*ast.BasicLit {
+	if k.keys == nil {
+		k.build()
+	}
+	return k.keys[key]
+}
+
+func (k *keyIndex) build() {
+	k.keys = map[string][]*ast.BasicLit{}
+	for _, file := range k.pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			callExpr, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			keyValues, ok := keyValueArgs(callExpr, k.pass)
+			if !ok {
+				return true
+			}
+			for i := 0; i < len(keyValues); i += 2 {
+				if lit, ok := keyValues[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if value, err := strconv.Unquote(lit.Value); err == nil {
+						k.keys[value] = append(k.keys[value], lit)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
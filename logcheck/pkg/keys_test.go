@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"testing"
+)
+
+func TestCanonicalKey(t *testing.T) {
+	for key, expectKey := range map[string]string{
+		"podName":         "podName",
+		"pod_name":        "podName",
+		"Pod-Name":        "podName",
+		"PodName":         "podName",
+		"pod_uid":         "podUID",
+		"Pod UID":         "podUID",
+		"node.ip":         "nodeIP",
+		"UID_value":       "UIDValue",
+		"HTTPServer_port": "HTTPServerPort",
+		"ipv4_address":    "ipv4Address",
+		"_leading":        "leading",
+		"1st":             "",
+		"测试":              "",
+		"":                "",
+	} {
+		t.Run(key, func(t *testing.T) {
+			if actualKey := canonicalKey(key); actualKey != expectKey {
+				t.Errorf("expected %q, got %q", expectKey, actualKey)
+			}
+		})
+	}
+}
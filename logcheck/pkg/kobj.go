@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// checkForKObj reports calls of klog.KObj, klog.KObjSlice and klog.KRef
+// which probably do not log what was intended.
+func checkForKObj(fexpr *ast.CallExpr, fName string, pass *analysis.Pass) {
+	if len(fexpr.Args) == 0 || fexpr.Ellipsis.IsValid() {
+		return
+	}
+	arg := fexpr.Args[0]
+	t := pass.TypesInfo.TypeOf(arg)
+	if t == nil || isNil(arg, pass) {
+		return
+	}
+
+	switch fName {
+	case "KObj":
+		if !isKMetadata(t) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				Message: fmt.Sprintf("klog.KObj needs a value which implements klog.KMetadata (GetName and GetNamespace). %s does not.", t),
+			})
+		}
+	case "KObjSlice":
+		var elem types.Type
+		switch u := t.Underlying().(type) {
+		case *types.Interface:
+			return
+		case *types.Slice:
+			elem = u.Elem()
+		case *types.Array:
+			elem = u.Elem()
+		default:
+			pass.Report(analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				Message: fmt.Sprintf("klog.KObjSlice needs a slice, not %s.", t),
+			})
+			return
+		}
+		if _, ok := elem.Underlying().(*types.Interface); !ok && !isKMetadata(elem) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				Message: fmt.Sprintf("klog.KObjSlice needs a slice of values which implement klog.KMetadata (GetName and GetNamespace). %s does not.", elem),
+			})
+		}
+	case "KRef":
+		if len(fexpr.Args) == 2 && isNameExpr(fexpr.Args[0]) && isNamespaceExpr(fexpr.Args[1]) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				Message: "The parameters of klog.KRef are namespace and name, in that order. These look swapped.",
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message: "Swap namespace and name",
+					TextEdits: []analysis.TextEdit{
+						{
+							Pos:     fexpr.Args[0].Pos(),
+							End:     fexpr.Args[0].End(),
+							NewText: []byte(formatNode(pass.Fset, fexpr.Args[1])),
+						},
+						{
+							Pos:     fexpr.Args[1].Pos(),
+							End:     fexpr.Args[1].End(),
+							NewText: []byte(formatNode(pass.Fset, fexpr.Args[0])),
+						},
+					},
+				}},
+			})
+		}
+	}
+}
+
+// checkForObjectValue reports values which are whole Kubernetes objects, like
+// a *v1.Pod. Those are large and usually klog.KObj was intended.
+func checkForObjectValue(key, arg ast.Expr, pass *analysis.Pass) {
+	t := pass.TypesInfo.TypeOf(arg)
+	if t == nil {
+		return
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return
+	}
+	pointerToStruct := false
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		_, pointerToStruct = ptr.Elem().Underlying().(*types.Struct)
+	}
+	_, isStruct := t.Underlying().(*types.Struct)
+	if !pointerToStruct && !isStruct {
+		return
+	}
+	fixable := isKMetadata(t)
+	if !fixable && !(isStruct && isKMetadata(types.NewPointer(t))) {
+		return
+	}
+
+	name := formatNode(pass.Fset, key)
+	if keyName, ok := constantKey(key, pass); ok {
+		name = fmt.Sprintf("%q", keyName)
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            arg.Pos(),
+		Message:        fmt.Sprintf("The value for key %s is a whole %s object. Log klog.KObj(...) instead unless the entire object is needed.", name, t),
+		SuggestedFixes: objectValueFixes(arg, fixable, pass),
+	})
+}
+
+func objectValueFixes(arg ast.Expr, fixable bool, pass *analysis.Pass) []analysis.SuggestedFix {
+	if !fixable {
+		return nil
+	}
+	klogName, edits, ok := importName(pass, arg.Pos(), "k8s.io/klog/v2", "klog")
+	if !ok {
+		return nil
+	}
+	edits = append(edits, analysis.TextEdit{
+		Pos:     arg.Pos(),
+		End:     arg.End(),
+		NewText: []byte(klogName + ".KObj(" + formatNode(pass.Fset, arg) + ")"),
+	})
+	return []analysis.SuggestedFix{{
+		Message:   "Use " + klogName + ".KObj",
+		TextEdits: edits,
+	}}
+}
+
+// isKMetadata checks whether the type implements the klog.KMetadata
+// interface.
+func isKMetadata(t types.Type) bool {
+	methods := types.NewMethodSet(t)
+	for _, name := range []string{"GetName", "GetNamespace"} {
+		selection := methods.Lookup(nil, name)
+		if selection == nil {
+			return false
+		}
+		if function, ok := selection.Obj().(*types.Func); !ok || !isFmtString(function) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNameExpr checks for expressions like pod.Name, pod.GetName() or name.
+func isNameExpr(expr ast.Expr) bool {
+	return lastIdentifier(expr) == "name" || lastIdentifier(expr) == "getname"
+}
+
+// isNamespaceExpr checks for expressions like pod.Namespace,
+// pod.GetNamespace(), namespace or ns.
+func isNamespaceExpr(expr ast.Expr) bool {
+	switch lastIdentifier(expr) {
+	case "namespace", "getnamespace", "ns":
+		return true
+	}
+	return false
+}
+
+// lastIdentifier returns the lower case name of the variable, field or
+// method at the end of an expression.
+func lastIdentifier(expr ast.Expr) string {
+	switch expr := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		return strings.ToLower(expr.Name)
+	case *ast.SelectorExpr:
+		return strings.ToLower(expr.Sel.Name)
+	case *ast.CallExpr:
+		if len(expr.Args) == 0 {
+			return lastIdentifier(expr.Fun)
+		}
+	}
+	return ""
+}
@@ -34,15 +34,30 @@ import (
 )
 
 const (
-	structuredCheck     = "structured"
-	parametersCheck     = "parameters"
-	contextualCheck     = "contextual"
-	withHelpersCheck    = "with-helpers"
-	verbosityZeroCheck  = "verbosity-zero"
-	verbosityErrorCheck = "verbosity-error"
-	keyCheck            = "key"
-	valueCheck          = "value"
-	deprecationsCheck   = "deprecations"
+	structuredCheck           = "structured"
+	parametersCheck           = "parameters"
+	contextualCheck           = "contextual"
+	withHelpersCheck          = "with-helpers"
+	verbosityZeroCheck        = "verbosity-zero"
+	verbosityErrorCheck       = "verbosity-error"
+	keyCheck                  = "key"
+	valueCheck                = "value"
+	deprecationsCheck         = "deprecations"
+	duplicateKeysCheck        = "duplicate-keys"
+	reservedKeysCheck         = "reserved-keys"
+	messageCheck              = "message"
+	constantMessageCheck      = "constant-message"
+	messageKeyValuesCheck     = "message-key-values"
+	sensitiveCheck            = "sensitive"
+	expensiveArgumentsCheck   = "expensive-arguments"
+	keyConsistencyCheck       = "key-consistency"
+	kobjCheck                 = "kobj"
+	contextPropagationCheck   = "context-propagation"
+	discardedLoggerCheck      = "discarded-logger"
+	contextualTransitiveCheck = "contextual-transitive"
+	todoCheck                 = "todo"
+	goroutineCheck            = "goroutine"
+	packageInitCheck          = "package-init"
 )
 
 type checks map[string]*bool
@@ -50,12 +65,43 @@ type checks map[string]*bool
 type Config struct {
 	enabled       checks
 	fileOverrides RegexpFilter
+
+	// errorKey is the key used for an error when logging it as a
+	// key/value pair.
+	errorKey string
+
+	// reservedKeys are keys which are used by the log output format
+	// and thus must not be used in key/value pairs.
+	reservedKeys keySet
+
+	// sensitiveKeys are words which indicate sensitive data when
+	// used as a word in a key.
+	sensitiveKeys keySet
+
+	// expensiveVerbosity is the lowest verbosity level for which
+	// expensive arguments get reported.
+	expensiveVerbosity int
+
+	// todoBudget is the number of klog.TODO calls which are allowed
+	// per package.
+	todoBudget todoBudget
+
+	// packageInitFollowCalls enables searching for log calls in functions
+	// which get called during package initialization.
+	packageInitFollowCalls bool
 }
 
 func (c Config) isEnabled(check string, filename string) bool {
 	return c.fileOverrides.Enabled(check, *c.enabled[check], filename)
 }
 
+// fileKey returns the name under which the file with the position is matched
+// against the per-file overrides of isEnabled: the package path followed by
+// the base name of the file.
+func fileKey(pass *analysis.Pass, pos token.Pos) string {
+	return pass.Pkg.Path() + "/" + path.Base(pass.Fset.Position(pos).Filename)
+}
+
 func (c *Config) SetEnabled(check string, enabled bool) error {
 	_, ok := c.enabled[check]
 	if !ok {
@@ -73,16 +119,34 @@ func (c *Config) ParseConfig(configContent string) error {
 func Analyser() (*analysis.Analyzer, *Config) {
 	c := Config{
 		enabled: checks{
-			structuredCheck:     new(bool),
-			parametersCheck:     new(bool),
-			contextualCheck:     new(bool),
-			withHelpersCheck:    new(bool),
-			verbosityZeroCheck:  new(bool),
-			verbosityErrorCheck: new(bool),
-			keyCheck:            new(bool),
-			valueCheck:          new(bool),
-			deprecationsCheck:   new(bool),
+			structuredCheck:           new(bool),
+			parametersCheck:           new(bool),
+			contextualCheck:           new(bool),
+			withHelpersCheck:          new(bool),
+			verbosityZeroCheck:        new(bool),
+			verbosityErrorCheck:       new(bool),
+			keyCheck:                  new(bool),
+			valueCheck:                new(bool),
+			deprecationsCheck:         new(bool),
+			duplicateKeysCheck:        new(bool),
+			reservedKeysCheck:         new(bool),
+			messageCheck:              new(bool),
+			constantMessageCheck:      new(bool),
+			messageKeyValuesCheck:     new(bool),
+			sensitiveCheck:            new(bool),
+			expensiveArgumentsCheck:   new(bool),
+			keyConsistencyCheck:       new(bool),
+			kobjCheck:                 new(bool),
+			contextPropagationCheck:   new(bool),
+			discardedLoggerCheck:      new(bool),
+			contextualTransitiveCheck: new(bool),
+			todoCheck:                 new(bool),
+			goroutineCheck:            new(bool),
+			packageInitCheck:          new(bool),
 		},
+		reservedKeys:  keySet{},
+		sensitiveKeys: keySet{},
+		todoBudget:    todoBudget{},
 	}
 	c.fileOverrides.validChecks = map[string]bool{}
 	for key := range c.enabled {
@@ -100,7 +164,30 @@ klog methods (Info, Infof, Error, Errorf, Warningf, etc).`)
 	logcheckFlags.BoolVar(c.enabled[keyCheck], prefix+keyCheck, true, `When true, logcheck will check whether name arguments are valid keys according to the guidelines in (https://github.com/kubernetes/community/blob/master/contributors/devel/sig-instrumentation/migration-to-structured-logging.md#name-arguments).`)
 	logcheckFlags.BoolVar(c.enabled[valueCheck], prefix+valueCheck, false, `When true, logcheck will check for problematic values (for example, types that have an incomplete fmt.Stringer implementation).`)
 	logcheckFlags.BoolVar(c.enabled[deprecationsCheck], prefix+deprecationsCheck, true, `When true, logcheck will analyze the usage of deprecated Klog function calls.`)
+	logcheckFlags.BoolVar(c.enabled[duplicateKeysCheck], prefix+duplicateKeysCheck, false, `When true, logcheck will check for keys which are passed more than once to the same logger.`)
+	logcheckFlags.BoolVar(c.enabled[reservedKeysCheck], prefix+reservedKeysCheck, false, `When true, logcheck will warn about keys which are reserved for the log output format.`)
+	logcheckFlags.BoolVar(c.enabled[messageCheck], prefix+messageCheck, false, `When true, logcheck will check whether messages follow the Kubernetes guidelines.`)
+	logcheckFlags.BoolVar(c.enabled[constantMessageCheck], prefix+constantMessageCheck, false, `When true, logcheck will check whether messages are constant strings.`)
+	logcheckFlags.BoolVar(c.enabled[messageKeyValuesCheck], prefix+messageKeyValuesCheck, false, `When true, logcheck will check for key/value pairs like "pod=" + name inside messages.`)
+	logcheckFlags.BoolVar(c.enabled[sensitiveCheck], prefix+sensitiveCheck, false, `When true, logcheck will warn about values and keys which indicate sensitive data.`)
+	logcheckFlags.BoolVar(c.enabled[expensiveArgumentsCheck], prefix+expensiveArgumentsCheck, false, `When true, logcheck will warn about expensive arguments for V(n) calls which are not guarded by Enabled().`)
+	logcheckFlags.BoolVar(c.enabled[keyConsistencyCheck], prefix+keyConsistencyCheck, false, `When true, logcheck will check whether keys are used consistently for the same types, also across packages.`)
+	logcheckFlags.BoolVar(c.enabled[kobjCheck], prefix+kobjCheck, false, `When true, logcheck will check calls of klog.KObj, klog.KObjSlice and klog.KRef and whole Kubernetes objects as values.`)
+	logcheckFlags.BoolVar(c.enabled[contextPropagationCheck], prefix+contextPropagationCheck, false, `When true, logcheck will warn about klog.Background, klog.TODO, context.Background and context.TODO calls when a context or logger is available.`)
+	logcheckFlags.BoolVar(c.enabled[discardedLoggerCheck], prefix+discardedLoggerCheck, false, `When true, logcheck will warn about statements which create a logger or klog.Verbose and then discard it.`)
+	logcheckFlags.BoolVar(c.enabled[contextualTransitiveCheck], prefix+contextualTransitiveCheck, false, `When true, logcheck will infer which functions call klog functions that are not allowed in contextual code, also through other functions, and warn about calling those functions where the contextual check is enabled.`)
+	logcheckFlags.BoolVar(c.enabled[todoCheck], prefix+todoCheck, false, `When true, logcheck will warn about klog.TODO calls, unless the package is within its todo-budget.`)
+	logcheckFlags.BoolVar(c.enabled[goroutineCheck], prefix+goroutineCheck, false, `When true, logcheck will warn about goroutines which log through global klog functions although a context or logger could have been passed to them.`)
+	logcheckFlags.BoolVar(c.enabled[packageInitCheck], prefix+packageInitCheck, false, `When true, logcheck will warn about log calls in init functions and package-level variable initializers.`)
 	logcheckFlags.Var(&c.fileOverrides, "config", `A file which overrides the global settings for checks on a per-file basis via regular expressions.`)
+	logcheckFlags.StringVar(&c.errorKey, "error-key", "err", `The key for an error when it gets logged as a key/value pair. Used by fixes which convert Error calls.`)
+	_ = c.reservedKeys.Set(defaultReservedKeys)
+	logcheckFlags.Var(c.reservedKeys, "reserved-keys", `A comma-separated list of keys which are used by the log output format. The key from -error-key is allowed for values of type error.`)
+	_ = c.sensitiveKeys.Set(defaultSensitiveKeys)
+	logcheckFlags.Var(c.sensitiveKeys, "sensitive-keys", `A comma-separated list of words which indicate sensitive data when a key contains them.`)
+	logcheckFlags.Var(c.todoBudget, "todo-budget", `A comma-separated list of <import path>=<count> entries with the number of klog.TODO calls which are allowed in a package. A <count> without import path applies to all other packages.`)
+	logcheckFlags.BoolVar(&c.packageInitFollowCalls, "package-init-follow-calls", false, `When true, the package-init check also reports calls of functions from the same package which log.`)
+	logcheckFlags.IntVar(&c.expensiveVerbosity, "expensive-arguments-verbosity", 4, `The lowest verbosity level for which the expensive-arguments check reports arguments.`)
 
 	// Use env variables as defaults. This is necessary when used as plugin
 	// for golangci-lint because of
@@ -120,6 +207,18 @@ klog methods (Info, Infof, Error, Errorf, Warningf, etc).`)
 			panic(fmt.Errorf("LOGCHECK_CONFIG=%q: %v", value, err))
 		}
 	}
+	logcheckFlags.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, prefix) || f.Name == "config" {
+			// Already handled above.
+			return
+		}
+		envVarName := "LOGCHECK_" + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := os.LookupEnv(envVarName); ok {
+			if err := f.Value.Set(value); err != nil {
+				panic(fmt.Errorf("%s=%q: %v", envVarName, value, err))
+			}
+		}
+	})
 
 	return &analysis.Analyzer{
 		Name: "logcheck",
@@ -128,7 +227,7 @@ klog methods (Info, Infof, Error, Errorf, Warningf, etc).`)
 			return run(pass, &c)
 		},
 		Flags:     logcheckFlags,
-		FactTypes: []analysis.Fact{new(warnContextual)},
+		FactTypes: []analysis.Fact{new(warnContextual), new(keyInventory), new(globalKlogCalls)},
 	}, &c
 }
 
@@ -142,19 +241,35 @@ func (w warnContextual) AFact() {}
 func (w warnContextual) String() string { return string(w) }
 
 func run(pass *analysis.Pass, c *Config) (interface{}, error) {
+	chains := inferGlobalKlogCalls(pass, c)
+	keys := newKeyIndex(pass)
 	for _, file := range pass.Files {
 		ast.Inspect(file, func(n ast.Node) bool {
 			switch n := n.(type) {
 			case *ast.CallExpr:
 				// We are interested in function calls, as we want to detect klog.* calls
 				// passing all function calls to checkForFunctionExpr
-				checkForFunctionExpr(n, pass, c)
+				checkForFunctionExpr(n, pass, c, keys)
 			case *ast.FuncType:
 				checkForContextAndLogger(n, n.Params, pass, c)
 			case *ast.IfStmt:
 				checkForIfEnabled(n, pass, c)
+			case *ast.GoStmt:
+				filename := fileKey(pass, n.Pos())
+				if c.isEnabled(goroutineCheck, filename) {
+					checkForGoroutineLogger(n, chains, pass)
+				}
+			case *ast.ExprStmt:
+				filename := fileKey(pass, n.Pos())
+				if c.isEnabled(discardedLoggerCheck, filename) {
+					checkForDiscardedLogger(n, pass)
+				}
 			case *ast.FuncDecl:
 				checkForComments(pass.TypesInfo.ObjectOf(n.Name), n.Doc, pass)
+				filename := fileKey(pass, n.Pos())
+				if n.Body != nil && c.isEnabled(duplicateKeysCheck, filename) {
+					checkForDuplicateKeys(n.Body, pass)
+				}
 			case *ast.InterfaceType:
 				for _, method := range n.Methods.List {
 					for _, name := range method.Names {
@@ -165,15 +280,21 @@ func run(pass *analysis.Pass, c *Config) (interface{}, error) {
 
 			return true
 		})
+		filename := fileKey(pass, file.Pos())
+		if c.isEnabled(duplicateKeysCheck, filename) {
+			checkForPackageLevelDuplicateKeys(file, pass)
+		}
 	}
+	checkForKeyConsistency(pass, c)
+	checkForTODO(pass, c)
+	checkForPackageInitLogging(pass, c)
 	return nil, nil
 }
 
 // checkForFunctionExpr checks for unstructured logging function, prints error if found any.
-func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
+func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config, keys *keyIndex) {
 	fun := fexpr.Fun
-	args := fexpr.Args
-	filename := pass.Pkg.Path() + "/" + path.Base(pass.Fset.Position(fexpr.Pos()).Filename)
+	filename := fileKey(pass, fexpr.Pos())
 	contextualCheckEnabled := c.isEnabled(contextualCheck, filename)
 
 	// Some function that is banned for contextual logging through comment?
@@ -186,6 +307,8 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 					Pos:     fun.Pos(),
 					Message: string(why),
 				})
+			} else if c.isEnabled(contextualTransitiveCheck, filename) {
+				checkForGlobalKlogCalls(object, fun.Pos(), pass)
 			}
 		}
 	}
@@ -201,6 +324,26 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 		valueCheckEnabled := c.isEnabled(valueCheck, filename)
 		keyCheckEnabled := c.isEnabled(keyCheck, filename)
 		parametersCheckEnabled := c.isEnabled(parametersCheck, filename)
+		kobjCheckEnabled := c.isEnabled(kobjCheck, filename)
+		var reservedKeys, sensitiveKeys keySet
+		if c.isEnabled(reservedKeysCheck, filename) {
+			reservedKeys = c.reservedKeys
+		}
+		if c.isEnabled(sensitiveCheck, filename) {
+			sensitiveKeys = c.sensitiveKeys
+		}
+
+		// Check for deprecated function usage. klog's V().Error is also
+		// reported by the structured check, so it is left to that one
+		// when enabled.
+		if c.isEnabled(deprecationsCheck, filename) &&
+			!(fName == "Error" && isKlogVerbose(selExpr.X, pass) && c.isEnabled(structuredCheck, filename)) {
+			checkForDeprecation(fexpr, selExpr, pass)
+		}
+
+		if c.isEnabled(contextPropagationCheck, filename) {
+			checkForContextPropagation(fexpr, selExpr, pass)
+		}
 
 		// Some method that is banned for contextual logging through comment?
 		if contextualCheckEnabled {
@@ -211,35 +354,31 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 					Pos:     selExpr.Sel.Pos(),
 					Message: string(why),
 				})
+			} else if c.isEnabled(contextualTransitiveCheck, filename) {
+				checkForGlobalKlogCalls(object, selExpr.Sel.Pos(), pass)
 			}
 		}
 
 		// Now we need to determine whether it is coming from klog.
 		if isKlog(selExpr.X, pass) {
+			if kobjCheckEnabled {
+				checkForKObj(fexpr, fName, pass)
+			}
 			if c.isEnabled(contextualCheck, filename) && !isContextualCall(fName) {
 				pass.Report(analysis.Diagnostic{
-					Pos:     fun.Pos(),
-					Message: fmt.Sprintf("function %q should not be used, convert to contextual logging", fName),
+					Pos:            fun.Pos(),
+					Message:        fmt.Sprintf("function %q should not be used, convert to contextual logging", fName),
+					SuggestedFixes: contextualFixes(fexpr, c.errorKey, pass),
 				})
 				return
 			}
 
-			// Check for Deprecated function usage
-			if c.isEnabled(deprecationsCheck, filename) {
-				message, deprecatedUse := isDeprecatedContextualCall(fName)
-				if deprecatedUse {
-					pass.Report(analysis.Diagnostic{
-						Pos:     fun.Pos(),
-						Message: message,
-					})
-				}
-			}
-
 			// Matching if any unstructured logging function is used.
 			if c.isEnabled(structuredCheck, filename) && isUnstructured(fName) {
 				pass.Report(analysis.Diagnostic{
-					Pos:     fun.Pos(),
-					Message: fmt.Sprintf("unstructured logging function %q should not be used", fName),
+					Pos:            fun.Pos(),
+					Message:        fmt.Sprintf("unstructured logging function %q should not be used", fName),
+					SuggestedFixes: structuredFixes(fexpr, selExpr, c.errorKey, pass),
 				})
 				return
 			}
@@ -247,39 +386,45 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 			// variadic input is a valid input to klog.Error*, klog.Info*, logr.Logger.Info and logr.Logger.Error
 			// functions. Hence checking the parameters for variadic input argument is excluded.
 			if !fexpr.Ellipsis.IsValid() {
-				if keyCheckEnabled || parametersCheckEnabled || valueCheckEnabled {
+				if keyCheckEnabled || parametersCheckEnabled || valueCheckEnabled || kobjCheckEnabled || reservedKeys != nil || sensitiveKeys != nil {
 					// if format specifier is used, check for arg length will most probably fail
-					// so check for format specifier first and skip if found
-					if parametersCheckEnabled && checkForFormatSpecifier(fexpr, pass) {
-						return
+					// so check for format specifier first and skip its arguments
+					formatArgs := 0
+					if parametersCheckEnabled {
+						formatArgs = checkForFormatSpecifier(fexpr, c.errorKey, pass)
 					}
-					switch fName {
-					case "InfoS", "LoggerWithValues":
-						kvCheck(args[1:], fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled)
-					case "ErrorS":
-						kvCheck(args[2:], fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled)
+					if keyValues, ok := keyValueArgs(fexpr, pass); ok {
+						kvCheck(keyValues[formatArgs:], fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled, kobjCheckEnabled, reservedKeys, sensitiveKeys, c.errorKey, keys)
 					}
 				}
 			}
+			if c.isEnabled(messageCheck, filename) {
+				checkForMessage(fexpr, pass)
+			}
+			if c.isEnabled(constantMessageCheck, filename) {
+				checkForConstantMessage(fexpr, c.errorKey, pass)
+			}
+			if c.isEnabled(messageKeyValuesCheck, filename) {
+				checkForInlineKeyValues(fexpr, pass)
+			}
+			if c.isEnabled(expensiveArgumentsCheck, filename) {
+				checkForExpensiveArguments(fexpr, pass, c.expensiveVerbosity)
+			}
 			// verbosity Zero Check
 			if c.isEnabled(verbosityZeroCheck, filename) {
 				checkForVerbosityZero(fexpr, pass)
 			}
 		} else if isGoLogger(selExpr.X, pass) {
 			if !fexpr.Ellipsis.IsValid() {
-				if keyCheckEnabled || parametersCheckEnabled || valueCheckEnabled {
+				if keyCheckEnabled || parametersCheckEnabled || valueCheckEnabled || kobjCheckEnabled || reservedKeys != nil || sensitiveKeys != nil {
 					// if format specifier is used, check for arg length will most probably fail
-					// so check for format specifier first and skip if found
-					if parametersCheckEnabled && checkForFormatSpecifier(fexpr, pass) {
-						return
+					// so check for format specifier first and skip its arguments
+					formatArgs := 0
+					if parametersCheckEnabled {
+						formatArgs = checkForFormatSpecifier(fexpr, c.errorKey, pass)
 					}
-					switch fName {
-					case "WithValues":
-						kvCheck(args, fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled)
-					case "Info":
-						kvCheck(args[1:], fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled)
-					case "Error":
-						kvCheck(args[2:], fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled)
+					if keyValues, ok := keyValueArgs(fexpr, pass); ok {
+						kvCheck(keyValues[formatArgs:], fun, pass, fName, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled, kobjCheckEnabled, reservedKeys, sensitiveKeys, c.errorKey, keys)
 					}
 				}
 			}
@@ -287,11 +432,24 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 				switch fName {
 				case "WithValues", "WithName":
 					pass.Report(analysis.Diagnostic{
-						Pos:     fun.Pos(),
-						Message: fmt.Sprintf("function %q should be called through klogr.Logger%s", fName, fName),
+						Pos:            fun.Pos(),
+						Message:        fmt.Sprintf("function %q should be called through klogr.Logger%s", fName, fName),
+						SuggestedFixes: withHelpersFixes(fexpr, selExpr, pass),
 					})
 				}
 			}
+			if c.isEnabled(messageCheck, filename) {
+				checkForMessage(fexpr, pass)
+			}
+			if c.isEnabled(constantMessageCheck, filename) {
+				checkForConstantMessage(fexpr, c.errorKey, pass)
+			}
+			if c.isEnabled(messageKeyValuesCheck, filename) {
+				checkForInlineKeyValues(fexpr, pass)
+			}
+			if c.isEnabled(expensiveArgumentsCheck, filename) {
+				checkForExpensiveArguments(fexpr, pass, c.expensiveVerbosity)
+			}
 			// verbosity Zero Check
 			if c.isEnabled(verbosityZeroCheck, filename) {
 				checkForVerbosityZero(fexpr, pass)
@@ -302,8 +460,9 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 				if innerCallExpr, ok := selExpr.X.(*ast.CallExpr); ok {
 					if innerSelExpr, ok := innerCallExpr.Fun.(*ast.SelectorExpr); ok && innerSelExpr.Sel.Name == "V" {
 						pass.Report(analysis.Diagnostic{
-							Pos:     innerSelExpr.Sel.Pos(),
-							Message: `V().Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V().Info(..., "err", err).`,
+							Pos:            innerSelExpr.Sel.Pos(),
+							Message:        fmt.Sprintf(`V().Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V().Info(..., %q, err).`, c.errorKey),
+							SuggestedFixes: verbosityErrorFixes(fexpr, selExpr, innerSelExpr, c.errorKey, pass),
 						})
 					}
 				}
@@ -312,19 +471,111 @@ func checkForFunctionExpr(fexpr *ast.CallExpr, pass *analysis.Pass, c *Config) {
 			isPackage(selExpr.X, "github.com/go-logr/logr", pass) &&
 			c.isEnabled(withHelpersCheck, filename) {
 			pass.Report(analysis.Diagnostic{
-				Pos:     fun.Pos(),
-				Message: fmt.Sprintf("function %q should be called through klogr.NewContext", fName),
+				Pos:            fun.Pos(),
+				Message:        fmt.Sprintf("function %q should be called through klogr.NewContext", fName),
+				SuggestedFixes: newContextFixes(selExpr, pass),
 			})
 		}
 
 	}
 }
 
+// withHelpersFixes turns logger.WithName(name) into
+// klog.LoggerWithName(logger, name) and logger.WithValues(kv...) into
+// klog.LoggerWithValues(logger, kv...). Only the parts around the logger
+// and the parameters get replaced, so nested calls can be fixed at the same
+// time.
+func withHelpersFixes(fexpr *ast.CallExpr, selExpr *ast.SelectorExpr, pass *analysis.Pass) []analysis.SuggestedFix {
+	klog, edits, ok := importName(pass, fexpr.Pos(), "k8s.io/klog/v2", "klog")
+	if !ok {
+		return nil
+	}
+	helper := "Logger" + selExpr.Sel.Name
+	separator := ", "
+	if len(fexpr.Args) == 0 {
+		separator = ""
+	}
+	edits = append(edits,
+		analysis.TextEdit{
+			Pos:     selExpr.X.Pos(),
+			End:     selExpr.X.Pos(),
+			NewText: []byte(klog + "." + helper + "("),
+		},
+		analysis.TextEdit{
+			Pos:     selExpr.X.End(),
+			End:     fexpr.Lparen + 1,
+			NewText: []byte(separator),
+		},
+	)
+	return []analysis.SuggestedFix{{
+		Message:   "Call klog." + helper,
+		TextEdits: edits,
+	}}
+}
+
+// newContextFixes replaces logr.NewContext with klog.NewContext.
+func newContextFixes(selExpr *ast.SelectorExpr, pass *analysis.Pass) []analysis.SuggestedFix {
+	klog, edits, ok := importName(pass, selExpr.Pos(), "k8s.io/klog/v2", "klog")
+	if !ok {
+		return nil
+	}
+	if ident, ok := selExpr.X.(*ast.Ident); ok {
+		edits = append(edits, removeImportIfUnused(pass, ident)...)
+	}
+	edits = append(edits, analysis.TextEdit{
+		Pos:     selExpr.X.Pos(),
+		End:     selExpr.X.End(),
+		NewText: []byte(klog),
+	})
+	return []analysis.SuggestedFix{{
+		Message:   "Call klog.NewContext",
+		TextEdits: edits,
+	}}
+}
+
+// verbosityErrorFixes returns two alternative fixes for logger.V(5).Error(err,
+// msg, kv...): removing the V call or logging with
+// logger.V(5).Info(msg, <errorKey>, err, kv...). The second one is not
+// possible when the key/value pairs are passed as a slice.
+func verbosityErrorFixes(fexpr *ast.CallExpr, selExpr, vSelExpr *ast.SelectorExpr, errorKey string, pass *analysis.Pass) []analysis.SuggestedFix {
+	fixes := []analysis.SuggestedFix{{
+		Message: "Remove V()",
+		TextEdits: []analysis.TextEdit{{
+			Pos: vSelExpr.X.End(),
+			End: selExpr.X.End(),
+		}},
+	}}
+	if fexpr.Ellipsis.IsValid() || len(fexpr.Args) < 2 {
+		return fixes
+	}
+	// A nil error carries no information, so it gets dropped.
+	newArgs := fmt.Sprintf("%s, %q, %s", formatNode(pass.Fset, fexpr.Args[1]), errorKey, formatNode(pass.Fset, fexpr.Args[0]))
+	if isNil(fexpr.Args[0], pass) {
+		newArgs = formatNode(pass.Fset, fexpr.Args[1])
+	}
+	fixes = append(fixes, analysis.SuggestedFix{
+		Message: "Use V().Info",
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     selExpr.Sel.Pos(),
+				End:     selExpr.Sel.End(),
+				NewText: []byte("Info"),
+			},
+			{
+				Pos:     fexpr.Args[0].Pos(),
+				End:     fexpr.Args[1].End(),
+				NewText: []byte(newArgs),
+			},
+		},
+	})
+	return fixes
+}
+
 // isKlogVerbose returns true if the type of the expression is klog.Verbose (=
 // the result of klog.V).
 func isKlogVerbose(expr ast.Expr, pass *analysis.Pass) bool {
 	if typeAndValue, ok := pass.TypesInfo.Types[expr]; ok {
-		switch t := typeAndValue.Type.(type) {
+		switch t := types.Unalias(typeAndValue.Type).(type) {
 		case *types.Named:
 			if typeName := t.Obj(); typeName != nil {
 				if pkg := typeName.Pkg(); pkg != nil {
@@ -371,15 +622,17 @@ func isPackage(expr ast.Expr, packagePath string, pass *analysis.Pass) bool {
 // isGoLogger checks whether an expression is logr.Logger.
 func isGoLogger(expr ast.Expr, pass *analysis.Pass) bool {
 	if typeAndValue, ok := pass.TypesInfo.Types[expr]; ok {
-		switch t := typeAndValue.Type.(type) {
-		case *types.Named:
-			if typeName := t.Obj(); typeName != nil {
-				if pkg := typeName.Pkg(); pkg != nil {
-					if typeName.Name() == "Logger" && pkg.Path() == "github.com/go-logr/logr" {
-						return true
-					}
-				}
-			}
+		return isNamedType(typeAndValue.Type, "github.com/go-logr/logr", "Logger")
+	}
+	return false
+}
+
+// isNamedType checks whether the type is the named type from the package,
+// directly or through an alias like klog.Logger.
+func isNamedType(t types.Type, packagePath, name string) bool {
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if typeName := named.Obj(); typeName != nil && typeName.Pkg() != nil {
+			return typeName.Name() == name && typeName.Pkg().Path() == packagePath
 		}
 	}
 	return false
@@ -404,18 +657,6 @@ func isUnstructured(fName string) bool {
 	return false
 }
 
-func isDeprecatedContextualCall(fName string) (message string, deprecatedUse bool) {
-	deprecatedContextualLogHelper := map[string]string{
-		"KObjs": "KObjSlice",
-	}
-	var replacementFunction string
-	if replacementFunction, deprecatedUse = deprecatedContextualLogHelper[fName]; deprecatedUse {
-		message = fmt.Sprintf(`Detected usage of deprecated helper "%s". Please switch to "%s" instead.`, fName, replacementFunction)
-		return
-	}
-	return
-}
-
 func isContextualCall(fName string) bool {
 	// List of klog functions we still want to use after migration to
 	// contextual logging. This is an allow list, so any new acceptable
@@ -453,24 +694,31 @@ func isContextualCall(fName string) bool {
 	return false
 }
 
-func checkForFormatSpecifier(expr *ast.CallExpr, pass *analysis.Pass) bool {
+// checkForFormatSpecifier reports format specifiers in calls which do not
+// format their parameters. It returns how many of the key/value parameters
+// are probably meant as values for the format specifiers in the message.
+// Those should not be checked as key/value pairs. Errors become key/value
+// pairs with errorKey in the suggested fix.
+func checkForFormatSpecifier(expr *ast.CallExpr, errorKey string, pass *analysis.Pass) int {
 	if selExpr, ok := expr.Fun.(*ast.SelectorExpr); ok {
 		// extracting function Name like Infof
 		fName := selExpr.Sel.Name
 		if strings.HasSuffix(fName, "f") {
 			// Allowed for calls like Infof.
-			return false
+			return 0
 		}
 		if specifier, found := hasFormatSpecifier(expr.Args); found {
+			formatArgs, fixes := formatSpecifierFixes(expr, errorKey, pass)
 			msg := fmt.Sprintf("logging function %q should not use format specifier %q", fName, specifier)
 			pass.Report(analysis.Diagnostic{
-				Pos:     expr.Fun.Pos(),
-				Message: msg,
+				Pos:            expr.Fun.Pos(),
+				Message:        msg,
+				SuggestedFixes: fixes,
 			})
-			return true
+			return formatArgs
 		}
 	}
-	return false
+	return 0
 }
 
 func hasFormatSpecifier(fArgs []ast.Expr) (string, bool) {
@@ -500,7 +748,7 @@ func checkForContextAndLogger(n ast.Node, params *ast.FieldList, pass *analysis.
 
 	for _, param := range params.List {
 		if typeAndValue, ok := pass.TypesInfo.Types[param.Type]; ok {
-			switch t := typeAndValue.Type.(type) {
+			switch t := types.Unalias(typeAndValue.Type).(type) {
 			case *types.Named:
 				if typeName := t.Obj(); typeName != nil {
 					if pkg := typeName.Pkg(); pkg != nil {
@@ -516,10 +764,17 @@ func checkForContextAndLogger(n ast.Node, params *ast.FieldList, pass *analysis.
 	}
 
 	if haveLogger && haveContext {
+		message := `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+		if funcType, ok := n.(*ast.FuncType); ok {
+			if decl := funcDeclOf(funcType, pass); decl != nil && isExportedAPI(decl, pass) {
+				message += ` There is no suggested fix because other packages might use this exported function.`
+			}
+		}
 		pass.Report(analysis.Diagnostic{
-			Pos:     n.Pos(),
-			End:     n.End(),
-			Message: `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`,
+			Pos:            n.Pos(),
+			End:            n.End(),
+			Message:        message,
+			SuggestedFixes: contextAndLoggerFixes(n, pass),
 		})
 	}
 }
@@ -586,9 +841,63 @@ func checkForIfEnabled(i *ast.IfStmt, pass *analysis.Pass, c *Config) {
 		End: i.End(),
 		Message: fmt.Sprintf("the result of %s should be stored in a variable and then be used multiple times: if %s := %s(); %s.Enabled() { ... %s.Info ... }",
 			funcCall, varName, funcCall, varName, varName),
+		SuggestedFixes: ifEnabledFixes(i, subCallExpr, varName, pass),
 	})
 }
 
+// ifEnabledFixes adds `<varName> := <vCall>` as init statement to the if
+// statement and replaces vCall with the variable in the condition and in
+// all branches. This is not possible when the if statement already has an
+// init statement or when the new variable would shadow some other variable
+// that is used inside the branches.
+func ifEnabledFixes(i *ast.IfStmt, vCallExpr *ast.CallExpr, varName string, pass *analysis.Pass) []analysis.SuggestedFix {
+	if i.Init != nil {
+		return nil
+	}
+	vCall := formatNode(pass.Fset, vCallExpr)
+	edits := []analysis.TextEdit{{
+		Pos:     vCallExpr.Pos(),
+		End:     vCallExpr.End(),
+		NewText: []byte(fmt.Sprintf("%s := %s; %s", varName, vCall, varName)),
+	}}
+
+	shadowed := false
+	inspect := func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if formatNode(pass.Fset, n) == vCall {
+				edits = append(edits, analysis.TextEdit{
+					Pos:     n.Pos(),
+					End:     n.End(),
+					NewText: []byte(varName),
+				})
+				// Don't check the identifiers inside the
+				// replaced call.
+				return false
+			}
+		case *ast.Ident:
+			if n.Name == varName {
+				if object := pass.TypesInfo.Uses[n]; object != nil && (object.Pos() < i.Pos() || object.Pos() >= i.End()) {
+					shadowed = true
+				}
+			}
+		}
+		return !shadowed
+	}
+	ast.Inspect(i.Body, inspect)
+	if i.Else != nil {
+		ast.Inspect(i.Else, inspect)
+	}
+	if shadowed {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Store the result of %s in %s", vCall, varName),
+		TextEdits: edits,
+	}}
+}
+
 func checkForVerbosityZero(fexpr *ast.CallExpr, pass *analysis.Pass) {
 	iselExpr, ok := fexpr.Fun.(*ast.SelectorExpr)
 	if !ok {
@@ -601,12 +910,58 @@ func checkForVerbosityZero(fexpr *ast.CallExpr, pass *analysis.Pass) {
 	if isVerbosityZero(expr) {
 		msg := "Logging with V(0) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed."
 		pass.Report(analysis.Diagnostic{
-			Pos:     fexpr.Fun.Pos(),
-			Message: msg,
+			Pos:            fexpr.Fun.Pos(),
+			Message:        msg,
+			SuggestedFixes: verbosityZeroFixes(iselExpr, pass),
 		})
 	}
 }
 
+// verbosityZeroFixes removes the V(0) call from klog.V(0).InfoS or
+// logger.V(0).Info. This is only possible when the function or method
+// without V has the same signature. That is not the case for example for
+// klog.V(0).Enabled, because there is no klog.Enabled.
+func verbosityZeroFixes(selExpr *ast.SelectorExpr, pass *analysis.Pass) []analysis.SuggestedFix {
+	vCallExpr := selExpr.X.(*ast.CallExpr)
+	vSelExpr := vCallExpr.Fun.(*ast.SelectorExpr)
+	function, ok := pass.TypesInfo.ObjectOf(selExpr.Sel).(*types.Func)
+	if !ok {
+		return nil
+	}
+
+	var replacement types.Object
+	if ident, ok := vSelExpr.X.(*ast.Ident); ok && isPackage(ident, "k8s.io/klog/v2", pass) {
+		replacement = pass.TypesInfo.Uses[ident].(*types.PkgName).Imported().Scope().Lookup(function.Name())
+	} else if t := pass.TypesInfo.TypeOf(vSelExpr.X); t != nil {
+		replacement, _, _ = types.LookupFieldOrMethod(t, true, function.Pkg(), function.Name())
+	}
+	if replacement, ok := replacement.(*types.Func); !ok || !sameSignature(function, replacement) {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "Remove V(0)",
+		TextEdits: []analysis.TextEdit{{
+			Pos: vSelExpr.X.End(),
+			End: vCallExpr.End(),
+		}},
+	}}
+}
+
+// sameSignature checks whether two functions or methods accept the same
+// parameters and return the same results, ignoring the receiver.
+func sameSignature(a, b *types.Func) bool {
+	sigA, okA := a.Type().(*types.Signature)
+	sigB, okB := b.Type().(*types.Signature)
+	if !okA || !okB {
+		return false
+	}
+	return types.Identical(
+		types.NewSignatureType(nil, nil, nil, sigA.Params(), sigA.Results(), sigA.Variadic()),
+		types.NewSignatureType(nil, nil, nil, sigB.Params(), sigB.Results(), sigB.Variadic()),
+	)
+}
+
 func isVerbosityZero(expr ast.Expr) bool {
 	subCallExpr, ok := expr.(*ast.CallExpr)
 	if !ok {
@@ -634,9 +989,42 @@ func isVerbosityZero(expr ast.Expr) bool {
 	return false
 }
 
+// keyValueArgs returns the key/value pairs which get passed to a structured
+// logging call like klog.InfoS, klog.ErrorS, klog.LoggerWithValues or
+// logr.Logger.Info/Error/WithValues.
+func keyValueArgs(fexpr *ast.CallExpr, pass *analysis.Pass) ([]ast.Expr, bool) {
+	selExpr, ok := fexpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, false
+	}
+	skip := -1
+	switch {
+	case isKlog(selExpr.X, pass):
+		switch selExpr.Sel.Name {
+		case "InfoS", "LoggerWithValues":
+			skip = 1
+		case "ErrorS":
+			skip = 2
+		}
+	case isGoLogger(selExpr.X, pass):
+		switch selExpr.Sel.Name {
+		case "WithValues":
+			skip = 0
+		case "Info":
+			skip = 1
+		case "Error":
+			skip = 2
+		}
+	}
+	if skip < 0 || len(fexpr.Args) < skip {
+		return nil, false
+	}
+	return fexpr.Args[skip:], true
+}
+
 // kvCheck check if all keys in keyAndValues are valid keys according to the guidelines
 // and that the values can be formatted.
-func kvCheck(keyValues []ast.Expr, fun ast.Expr, pass *analysis.Pass, funName string, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled bool) {
+func kvCheck(keyValues []ast.Expr, fun ast.Expr, pass *analysis.Pass, funName string, keyCheckEnabled, parametersCheckEnabled, valueCheckEnabled, kobjCheckEnabled bool, reservedKeys, sensitiveKeys keySet, errorKey string, keys *keyIndex) {
 	if len(keyValues)%2 != 0 {
 		pass.Report(analysis.Diagnostic{
 			Pos:     fun.Pos(),
@@ -649,16 +1037,31 @@ func kvCheck(keyValues []ast.Expr, fun ast.Expr, pass *analysis.Pass, funName st
 		switch index % 2 {
 		case 0:
 			// Key in key/value pair.
-			checkKey(arg, pass, keyCheckEnabled, parametersCheckEnabled)
+			checkKey(arg, keyValues[index+1], pass, keyCheckEnabled, parametersCheckEnabled, reservedKeys, errorKey, keys)
 		case 1:
 			// Value in key/value pair.
-			checkValue(arg, pass, valueCheckEnabled)
+			checkValue(keyValues[index-1], arg, pass, valueCheckEnabled, kobjCheckEnabled, sensitiveKeys)
 		}
 	}
 }
 
-// checkKey checks the key in a key/value pair.
-func checkKey(arg ast.Expr, pass *analysis.Pass, keyCheckEnabled, parametersCheckEnabled bool) {
+// keyMatchRe matches keys which follow the naming guidelines.
+var keyMatchRe = regexp.MustCompile(`(^[A-Z]{2,}|^[a-z])[[:alnum:]]*$`)
+
+// checkKey checks the key in a key/value pair. A reserved key which is also
+// the key for errors is allowed for values of type error.
+func checkKey(arg, value ast.Expr, pass *analysis.Pass, keyCheckEnabled, parametersCheckEnabled bool, reservedKeys keySet, errorKey string, keys *keyIndex) {
+	if key, ok := constantKey(arg, pass); ok && reservedKeys[key] && !(key == errorKey && isError(pass.TypesInfo.TypeOf(value))) {
+		msg := fmt.Sprintf("Key %q is reserved for the log output format and should not be used.", key)
+		if key == errorKey {
+			msg = fmt.Sprintf("Key %q is reserved for values of type error.", key)
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     arg.Pos(),
+			Message: msg,
+		})
+	}
+
 	if !keyCheckEnabled && !parametersCheckEnabled {
 		return
 	}
@@ -692,19 +1095,26 @@ func checkKey(arg ast.Expr, pass *analysis.Pass, keyCheckEnabled, parametersChec
 		}
 	case keyCheckEnabled:
 		// This is the stricter check.
-		keyMatchRe := regexp.MustCompile(`(^[A-Z]{2,}|^[a-z])[[:alnum:]]*$`)
 		match := keyMatchRe.Match([]byte(strings.Trim(lit.Value, "\"")))
 		if !match {
 			pass.Report(analysis.Diagnostic{
-				Pos:     arg.Pos(),
-				Message: fmt.Sprintf("Key positional arguments %s are expected to be alphanumeric and start with either one lowercase or two uppercase letters. Please refer to https://github.com/kubernetes/community/blob/master/contributors/devel/sig-instrumentation/migration-to-structured-logging.md#name-arguments.", lit.Value),
+				Pos:            arg.Pos(),
+				Message:        fmt.Sprintf("Key positional arguments %s are expected to be alphanumeric and start with either one lowercase or two uppercase letters. Please refer to https://github.com/kubernetes/community/blob/master/contributors/devel/sig-instrumentation/migration-to-structured-logging.md#name-arguments.", lit.Value),
+				SuggestedFixes: keyFixes(lit, pass, keys),
 			})
 		}
 	}
 }
 
 // checkValue checks the value in a key/value pair.
-func checkValue(arg ast.Expr, pass *analysis.Pass, valueCheckEnabled bool) {
+func checkValue(key, arg ast.Expr, pass *analysis.Pass, valueCheckEnabled, kobjCheckEnabled bool, sensitiveKeys keySet) {
+	if sensitiveKeys != nil {
+		checkForSensitiveData(key, arg, pass, sensitiveKeys)
+	}
+	if kobjCheckEnabled {
+		checkForObjectValue(key, arg, pass)
+	}
+
 	if !valueCheckEnabled {
 		return
 	}
@@ -754,7 +1164,7 @@ func isWrapperStruct(t types.Type) bool {
 	if ptr, ok := t.(*types.Pointer); ok {
 		t = ptr.Elem()
 	}
-	if named, ok := t.(*types.Named); ok {
+	if named, ok := types.Unalias(t).(*types.Named); ok {
 		t = named.Underlying()
 	}
 	if strct, ok := t.(*types.Struct); ok {
@@ -0,0 +1,350 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// messageArg returns the message parameter of a structured logging call,
+// if it has one.
+func messageArg(fexpr *ast.CallExpr, pass *analysis.Pass) ast.Expr {
+	keyValues, ok := keyValueArgs(fexpr, pass)
+	msgIndex := len(fexpr.Args) - len(keyValues) - 1
+	if !ok || msgIndex < 0 {
+		return nil
+	}
+	switch fexpr.Fun.(*ast.SelectorExpr).Sel.Name {
+	case "WithValues", "LoggerWithValues":
+		return nil
+	}
+	return fexpr.Args[msgIndex]
+}
+
+// checkForMessage checks that a constant message follows the Kubernetes
+// guidelines: it must not be empty, starts with a capital letter and has no
+// trailing period or surrounding whitespace.
+func checkForMessage(fexpr *ast.CallExpr, pass *analysis.Pass) {
+	msgArg := messageArg(fexpr, pass)
+	if msgArg == nil {
+		return
+	}
+	typeAndValue, ok := pass.TypesInfo.Types[msgArg]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return
+	}
+	msg := constant.StringVal(typeAndValue.Value)
+	fixed := fixMessage(msg)
+
+	var problem string
+	switch {
+	case strings.TrimSpace(msg) == "":
+		problem = "Log message should not be empty."
+	case strings.TrimSpace(msg) != msg:
+		problem = "Log message should not have leading or trailing whitespace."
+	case hasTrailingPeriod(msg):
+		problem = "Log message should not end with a period."
+	case fixed != msg:
+		problem = "Log message should start with a capital letter."
+	default:
+		return
+	}
+
+	diagnostic := analysis.Diagnostic{
+		Pos:     msgArg.Pos(),
+		Message: problem,
+	}
+	if lit, ok := msgArg.(*ast.BasicLit); ok && lit.Kind == token.STRING && fixed != "" {
+		diagnostic.SuggestedFixes = []analysis.SuggestedFix{{
+			Message: "Use " + strconv.Quote(fixed),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     lit.Pos(),
+				End:     lit.End(),
+				NewText: []byte(strconv.Quote(fixed)),
+			}},
+		}}
+	}
+	pass.Report(diagnostic)
+}
+
+// fixMessage removes surrounding whitespace and a trailing period and turns
+// the first letter into upper case. The first word is left alone if it
+// contains upper case letters, because then it probably is an identifier like
+// "gRPC".
+func fixMessage(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if hasTrailingPeriod(msg) {
+		msg = strings.TrimSpace(strings.TrimSuffix(msg, "."))
+	}
+	firstWord, _, _ := strings.Cut(msg, " ")
+	if strings.IndexFunc(firstWord, unicode.IsUpper) >= 0 {
+		return msg
+	}
+	r, size := utf8.DecodeRuneInString(msg)
+	if size == 0 {
+		return ""
+	}
+	return string(unicode.ToUpper(r)) + msg[size:]
+}
+
+// hasTrailingPeriod checks whether the message ends with a period which
+// ends a sentence. An ellipsis like in "Waiting..." is intentional.
+func hasTrailingPeriod(msg string) bool {
+	return strings.HasSuffix(msg, ".") && !strings.HasSuffix(msg, "...")
+}
+
+// checkForConstantMessage reports messages which are not constant, like
+// fmt.Sprintf("...", ...), "..." + name or err.Error().
+func checkForConstantMessage(fexpr *ast.CallExpr, errorKey string, pass *analysis.Pass) {
+	msgArg := messageArg(fexpr, pass)
+	if msgArg == nil {
+		return
+	}
+	if typeAndValue, ok := pass.TypesInfo.Types[msgArg]; !ok || typeAndValue.Value != nil {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            msgArg.Pos(),
+		Message:        "Log message should be a constant string. Move the variable parts into key/value pairs.",
+		SuggestedFixes: sprintfMessageFixes(fexpr, msgArg, errorKey, pass),
+	})
+}
+
+// sprintfMessageFixes converts a fmt.Sprintf call as message into a constant
+// message and key/value pairs, the same way as for format specifiers.
+func sprintfMessageFixes(fexpr *ast.CallExpr, msgArg ast.Expr, errorKey string, pass *analysis.Pass) []analysis.SuggestedFix {
+	callExpr, ok := msgArg.(*ast.CallExpr)
+	if !ok || len(callExpr.Args) == 0 || callExpr.Ellipsis.IsValid() {
+		return nil
+	}
+	function, ok := calledObject(callExpr, pass).(*types.Func)
+	if !ok || function.Pkg() == nil || function.Pkg().Path() != "fmt" || function.Name() != "Sprintf" {
+		return nil
+	}
+	typeAndValue, ok := pass.TypesInfo.Types[callExpr.Args[0]]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return nil
+	}
+	parts, ok := parseFormat(constant.StringVal(typeAndValue.Value))
+	if !ok || len(parts) != len(callExpr.Args) {
+		return nil
+	}
+	usedKeys := map[string]bool{}
+	keyValues, _ := keyValueArgs(fexpr, pass)
+	for i := 0; i < len(keyValues); i += 2 {
+		if key, ok := constantKey(keyValues[i], pass); ok {
+			usedKeys[key] = true
+		}
+	}
+	newArgs, ok := formatKeyValues(parts, callExpr.Args[1:], usedKeys, errorKey, errorArg(fexpr, msgArg, pass), pass)
+	if !ok {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: "Turn format specifiers into key/value pairs",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     msgArg.Pos(),
+			End:     msgArg.End(),
+			NewText: []byte(strings.Join(newArgs, ", ")),
+		}},
+	}}
+}
+
+// messagePart is a piece of a message which gets concatenated with "+".
+// Parts which are not constant have an expression instead of text.
+type messagePart struct {
+	text string
+	expr ast.Expr
+}
+
+// messageParts splits a message into its constant and variable parts.
+func messageParts(expr ast.Expr, pass *analysis.Pass) []messagePart {
+	if typeAndValue, ok := pass.TypesInfo.Types[expr]; ok && typeAndValue.Value != nil && typeAndValue.Value.Kind() == constant.String {
+		return []messagePart{{text: constant.StringVal(typeAndValue.Value)}}
+	}
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return messageParts(e.X, pass)
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return append(messageParts(e.X, pass), messageParts(e.Y, pass)...)
+		}
+	}
+	return []messagePart{{expr: expr}}
+}
+
+type messageTokenKind int
+
+const (
+	wordToken messageTokenKind = iota
+	spaceToken
+	equalsToken
+	colonToken
+	quotedToken
+	variableToken
+	otherToken
+)
+
+type messageToken struct {
+	kind messageTokenKind
+	text string
+}
+
+// tokenizeMessage splits the message into words, whitespace, quoted strings
+// and punctuation. Each variable part becomes a single token.
+func tokenizeMessage(parts []messagePart) []messageToken {
+	var tokens []messageToken
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.", r)
+	}
+	for _, part := range parts {
+		if part.expr != nil {
+			tokens = append(tokens, messageToken{kind: variableToken})
+			continue
+		}
+		text := part.text
+		for text != "" {
+			r, size := utf8.DecodeRuneInString(text)
+			end := size
+			kind := otherToken
+			switch {
+			case isWordRune(r):
+				kind = wordToken
+				for end < len(text) {
+					r, size := utf8.DecodeRuneInString(text[end:])
+					if !isWordRune(r) {
+						break
+					}
+					end += size
+				}
+			case unicode.IsSpace(r):
+				kind = spaceToken
+				for end < len(text) {
+					r, size := utf8.DecodeRuneInString(text[end:])
+					if !unicode.IsSpace(r) {
+						break
+					}
+					end += size
+				}
+			case r == '"' || r == '\'':
+				if i := strings.IndexRune(text[size:], r); i >= 0 {
+					kind = quotedToken
+					end = size + i + size
+				}
+			case r == '=':
+				kind = equalsToken
+			case r == ':':
+				kind = colonToken
+			}
+			tokens = append(tokens, messageToken{kind: kind, text: text[:end]})
+			text = text[end:]
+		}
+	}
+	return tokens
+}
+
+// findInlineKeyValue looks for "key=value" or "key: value" in a message. For
+// the second form, the value must be a number, a quoted string or a variable
+// because otherwise normal prose like "Sync failed: timeout" would match.
+// It returns the key and the separator after it.
+func findInlineKeyValue(tokens []messageToken) (string, string) {
+	isKey := func(t messageToken) bool {
+		r, _ := utf8.DecodeRuneInString(t.text)
+		return t.kind == wordToken && unicode.IsLetter(r)
+	}
+	isValue := func(t messageToken) bool {
+		r, _ := utf8.DecodeRuneInString(t.text)
+		return t.kind == variableToken || t.kind == quotedToken || t.kind == wordToken && unicode.IsDigit(r)
+	}
+	for i := 0; i+2 < len(tokens); i++ {
+		if !isKey(tokens[i]) {
+			continue
+		}
+		switch tokens[i+1].kind {
+		case equalsToken:
+			switch tokens[i+2].kind {
+			case wordToken, quotedToken, variableToken:
+				return tokens[i].text, "="
+			}
+		case colonToken:
+			if tokens[i+2].kind == spaceToken && i+3 < len(tokens) && isValue(tokens[i+3]) {
+				return tokens[i].text, ":"
+			}
+		}
+	}
+	return "", ""
+}
+
+// checkForInlineKeyValues reports messages like "Syncing pod=" + name which
+// contain key/value pairs.
+func checkForInlineKeyValues(fexpr *ast.CallExpr, pass *analysis.Pass) {
+	msgArg := messageArg(fexpr, pass)
+	if msgArg == nil {
+		return
+	}
+	parts := messageParts(msgArg, pass)
+	key, separator := findInlineKeyValue(tokenizeMessage(parts))
+	if key == "" {
+		return
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos:            msgArg.Pos(),
+		Message:        fmt.Sprintf("Log message should not contain key/value pairs like %q. Pass them as additional parameters instead.", key+separator),
+		SuggestedFixes: inlineKeyValueFixes(fexpr, msgArg, parts, key, separator, pass),
+	})
+}
+
+// inlineKeyValueFixes handles the common case of a constant message followed
+// by a variable value, like "Syncing pod=" + name. It becomes
+// "Syncing", "pod", name.
+func inlineKeyValueFixes(fexpr *ast.CallExpr, msgArg ast.Expr, parts []messagePart, key, separator string, pass *analysis.Pass) []analysis.SuggestedFix {
+	if len(parts) != 2 || parts[0].expr != nil || parts[1].expr == nil {
+		return nil
+	}
+	text := strings.TrimRightFunc(parts[0].text, unicode.IsSpace)
+	if !strings.HasSuffix(text, key+separator) || !keyMatchRe.MatchString(key) {
+		return nil
+	}
+	msg := strings.TrimRight(strings.TrimSuffix(text, key+separator), " .:,;=-")
+	if msg == "" {
+		return nil
+	}
+	keyValues, _ := keyValueArgs(fexpr, pass)
+	for i := 0; i < len(keyValues); i += 2 {
+		if usedKey, ok := constantKey(keyValues[i], pass); ok && usedKey == key {
+			return nil
+		}
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Pass %q as key/value pair", key),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     msgArg.Pos(),
+			End:     msgArg.End(),
+			NewText: []byte(fmt.Sprintf("%q, %q, %s", msg, key, formatNode(pass.Fset, parts[1].expr))),
+		}},
+	}}
+}
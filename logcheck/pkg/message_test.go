@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestFindInlineKeyValue(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		parts           []messagePart
+		expectKey       string
+		expectSeparator string
+	}{
+		{
+			name:            "key=value",
+			parts:           []messagePart{{text: "done: count=5"}},
+			expectKey:       "count",
+			expectSeparator: "=",
+		},
+		{
+			name:            "key=variable",
+			parts:           []messagePart{{text: "Syncing pod="}, {expr: &ast.Ident{Name: "name"}}},
+			expectKey:       "pod",
+			expectSeparator: "=",
+		},
+		{
+			name:            "key: variable",
+			parts:           []messagePart{{text: "Syncing pod: "}, {expr: &ast.Ident{Name: "name"}}},
+			expectKey:       "pod",
+			expectSeparator: ":",
+		},
+		{
+			name:            "key: quoted",
+			parts:           []messagePart{{text: `Deleted pod: 'foo'`}},
+			expectKey:       "pod",
+			expectSeparator: ":",
+		},
+		{
+			name:  "prose",
+			parts: []messagePart{{text: "Sync failed: timeout"}},
+		},
+		{
+			name:  "comparison",
+			parts: []messagePart{{text: "a==b"}},
+		},
+		{
+			name:  "URL",
+			parts: []messagePart{{text: "See http://example.com/?a"}},
+		},
+		{
+			name:  "unterminated quote",
+			parts: []messagePart{{text: `Pod: "foo`}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, separator := findInlineKeyValue(tokenizeMessage(tc.parts))
+			if key != tc.expectKey || separator != tc.expectSeparator {
+				t.Errorf("expected %q %q, got %q %q", tc.expectKey, tc.expectSeparator, key, separator)
+			}
+		})
+	}
+}
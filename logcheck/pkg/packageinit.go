@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkForPackageInitLogging reports log calls in init functions and
+// package-level variable initializers. Those run before the program had a
+// chance to configure logging. With package-init-follow-calls, functions from
+// the same package which get called there are searched, too.
+func checkForPackageInitLogging(pass *analysis.Pass, c *Config) {
+	for _, file := range pass.Files {
+		filename := fileKey(pass, file.Pos())
+		if !c.isEnabled(packageInitCheck, filename) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Name.Name == "init" && decl.Recv == nil && decl.Body != nil {
+					checkInitCode(decl.Body, c.packageInitFollowCalls, pass)
+				}
+			case *ast.GenDecl:
+				if decl.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					for _, value := range spec.(*ast.ValueSpec).Values {
+						checkInitCode(value, c.packageInitFollowCalls, pass)
+					}
+				}
+			}
+		}
+	}
+}
+
+func checkInitCode(node ast.Node, followCalls bool, pass *analysis.Pass) {
+	inspectInitCode(node, pass, func(callExpr *ast.CallExpr) {
+		if name := logCallName(callExpr, pass); name != "" {
+			pass.Report(analysis.Diagnostic{
+				Pos:     callExpr.Pos(),
+				Message: fmt.Sprintf("%s is called during package initialization, before logging is configured.", name),
+			})
+			return
+		}
+		if !followCalls {
+			return
+		}
+		function, ok := calledObject(callExpr, pass).(*types.Func)
+		if !ok {
+			return
+		}
+		if chain := initLogChain(function, map[*types.Func]bool{}, pass); chain != nil {
+			pass.Report(analysis.Diagnostic{
+				Pos:     callExpr.Pos(),
+				Message: fmt.Sprintf("%s logs during package initialization, before logging is configured (%s).", function.Name(), strings.Join(chain, " -> ")),
+			})
+		}
+	})
+}
+
+// initLogChain returns the chain of calls which leads from the function to a
+// log call, if there is one. Only functions in the current package are
+// searched.
+func initLogChain(function *types.Func, visited map[*types.Func]bool, pass *analysis.Pass) []string {
+	if visited[function] {
+		return nil
+	}
+	visited[function] = true
+	decl := declOfFunction(function, pass)
+	if decl == nil || decl.Body == nil {
+		return nil
+	}
+	var chain []string
+	inspectInitCode(decl.Body, pass, func(callExpr *ast.CallExpr) {
+		if chain != nil {
+			return
+		}
+		if name := logCallName(callExpr, pass); name != "" {
+			chain = []string{functionName(function), name}
+			return
+		}
+		if callee, ok := calledObject(callExpr, pass).(*types.Func); ok {
+			if calleeChain := initLogChain(callee, visited, pass); calleeChain != nil {
+				chain = append([]string{functionName(function)}, calleeChain...)
+			}
+		}
+	})
+	return chain
+}
+
+// inspectInitCode invokes the callback for all calls in the node which get
+// executed when the node runs. Function literals are only entered when they
+// get called right away.
+func inspectInitCode(node ast.Node, pass *analysis.Pass, callback func(callExpr *ast.CallExpr)) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			callback(n)
+			if funcLit, ok := n.Fun.(*ast.FuncLit); ok {
+				inspectInitCode(funcLit.Body, pass, callback)
+			}
+		}
+		return true
+	})
+}
+
+// logCallName returns the name of a klog or logr function or method which
+// emits a log entry, like "klog.InfoS" or "logger.Info". Those are the
+// unstructured klog functions and the calls with key/value pairs, except for
+// the ones which only add them to a logger.
+func logCallName(callExpr *ast.CallExpr, pass *analysis.Pass) string {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	name := selExpr.Sel.Name
+	_, structured := keyValueArgs(callExpr, pass)
+	switch {
+	case name == "WithValues" || name == "LoggerWithValues":
+		return ""
+	case isKlogVerbose(selExpr.X, pass) && (structured || isUnstructured(name)):
+		return "klog.V()." + name
+	case isKlog(selExpr.X, pass) && (structured || isUnstructured(name)):
+		return "klog." + name
+	case structured:
+		return formatNode(pass.Fset, selExpr)
+	}
+	return ""
+}
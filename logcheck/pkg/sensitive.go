@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// defaultSensitiveKeys are words which indicate that a value contains
+// credentials when they are a word of a key, like in "adminPassword" or
+// "passwordHash".
+const defaultSensitiveKeys = "password,token,secret"
+
+// referenceWords turn a key into a reference to sensitive data instead of the
+// data itself when they directly follow a sensitive word, like in "secretName"
+// or "tokenFile". Such keys are not flagged.
+var referenceWords = keySet{"name": true, "namespace": true, "ref": true, "file": true, "path": true}
+
+// checkForSensitiveData reports keys which indicate sensitive data and values
+// with fields that are marked as sensitive with a datapolicy tag.
+func checkForSensitiveData(key, value ast.Expr, pass *analysis.Pass, sensitiveKeys keySet) {
+	if name, ok := constantKey(key, pass); ok {
+		if isSensitiveKey(name, sensitiveKeys) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     key.Pos(),
+				Message: fmt.Sprintf("Key %q indicates sensitive data which should not be logged.", name),
+			})
+			return
+		}
+	}
+
+	t := pass.TypesInfo.TypeOf(value)
+	if t == nil {
+		return
+	}
+	if field, tag := findDataPolicy(t, "", map[types.Type]bool{}); field != "" {
+		pass.Report(analysis.Diagnostic{
+			Pos:     value.Pos(),
+			Message: fmt.Sprintf("The type %s contains %s with datapolicy %q, which should not be logged.", t.String(), field, tag),
+		})
+	}
+}
+
+// isSensitiveKey checks whether any word of the key is a sensitive word which
+// is not followed by one of the referenceWords.
+func isSensitiveKey(key string, sensitiveKeys keySet) bool {
+	words := splitWords(key)
+	for i, word := range words {
+		if !sensitiveKeys[strings.ToLower(word)] {
+			continue
+		}
+		if i+1 < len(words) && referenceWords[strings.ToLower(words[i+1])] {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// findDataPolicy walks through the type, including pointers, slices, arrays,
+// maps and struct fields, and returns the first field with a datapolicy tag.
+// Types which implement fmt.Stringer or logr.Marshaler are not checked because
+// they control themselves how they get logged.
+func findDataPolicy(t types.Type, path string, seen map[types.Type]bool) (string, string) {
+	if seen[t] {
+		return "", ""
+	}
+	seen[t] = true
+	if implementsLogging(t) {
+		return "", ""
+	}
+
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return findDataPolicy(t.Elem(), path, seen)
+	case *types.Slice:
+		return findDataPolicy(t.Elem(), path, seen)
+	case *types.Array:
+		return findDataPolicy(t.Elem(), path, seen)
+	case *types.Map:
+		if field, tag := findDataPolicy(t.Key(), path, seen); field != "" {
+			return field, tag
+		}
+		return findDataPolicy(t.Elem(), path, seen)
+	case *types.Named:
+		return findDataPolicy(t.Underlying(), path, seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			fieldPath := field.Name()
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+			if tag := reflect.StructTag(t.Tag(i)).Get("datapolicy"); tag != "" {
+				return fieldPath, tag
+			}
+			if field, tag := findDataPolicy(field.Type(), fieldPath, seen); field != "" {
+				return field, tag
+			}
+		}
+	}
+	return "", ""
+}
+
+// implementsLogging checks for a String or MarshalLog method in the method set
+// of the type itself. A pointer receiver is not enough for a value because
+// then the value gets logged with all of its fields.
+func implementsLogging(t types.Type) bool {
+	for _, name := range []string{"String", "MarshalLog"} {
+		if obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name); obj != nil {
+			if _, ok := obj.(*types.Func); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// structuredReplacements maps printf-style klog functions to the structured
+// function which replaces them. klog has no WarningS, so warnings become
+// informational messages, as recommended by the migration guide. The fix
+// message points that out.
+var structuredReplacements = map[string]string{
+	"Infof":    "InfoS",
+	"Warningf": "InfoS",
+	"Errorf":   "ErrorS",
+}
+
+// structuredFixes returns a fix which converts a call like
+// klog.Errorf("Failed to sync pod %s: %v", pod.Name, err) into
+// klog.ErrorS(err, "Failed to sync pod", "podName", pod.Name). No fix is
+// returned when the call cannot be converted mechanically, for example
+// because the format string is not a constant or because no key can be
+// derived for one of the arguments.
+func structuredFixes(fexpr *ast.CallExpr, selExpr *ast.SelectorExpr, errorKey string, pass *analysis.Pass) []analysis.SuggestedFix {
+	newName, ok := structuredReplacements[selExpr.Sel.Name]
+	if !ok || fexpr.Ellipsis.IsValid() || len(fexpr.Args) == 0 {
+		return nil
+	}
+	typeAndValue, ok := pass.TypesInfo.Types[fexpr.Args[0]]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return nil
+	}
+	parts, ok := parseFormat(constant.StringVal(typeAndValue.Value))
+	args := fexpr.Args[1:]
+	if !ok || len(parts) != len(args)+1 {
+		return nil
+	}
+
+	// A trailing error becomes the error parameter of ErrorS. For InfoS,
+	// it is logged with errorKey, like any other error in the arguments.
+	errText := "nil"
+	if newName == "ErrorS" && len(args) > 0 && isError(pass.TypesInfo.TypeOf(args[len(args)-1])) {
+		errText = formatNode(pass.Fset, args[len(args)-1])
+		args = args[:len(args)-1]
+	}
+
+	var newArgs []string
+	if newName == "ErrorS" {
+		newArgs = append(newArgs, errText)
+	}
+	msgAndKeyValues, ok := formatKeyValues(parts, args, nil, errorKey, nil, pass)
+	if !ok {
+		return nil
+	}
+	newArgs = append(newArgs, msgAndKeyValues...)
+
+	message := "Convert to " + newName
+	if selExpr.Sel.Name == "Warningf" {
+		message += ", which logs the warning as an informational message"
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: message,
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     selExpr.Sel.Pos(),
+				End:     selExpr.Sel.End(),
+				NewText: []byte(newName),
+			},
+			{
+				Pos:     fexpr.Args[0].Pos(),
+				End:     fexpr.Args[len(fexpr.Args)-1].End(),
+				NewText: []byte(strings.Join(newArgs, ", ")),
+			},
+		},
+	}}
+}
+
+// formatSpecifierFixes handles a message with format specifiers in a
+// structured logging call like klog.InfoS("Got %d pods", n). The values for
+// the format specifiers are the first key/value parameters. It returns how
+// many of them there are and a fix which turns the call into
+// klog.InfoS("Got pods", "n", n).
+func formatSpecifierFixes(fexpr *ast.CallExpr, errorKey string, pass *analysis.Pass) (int, []analysis.SuggestedFix) {
+	msgArg := messageArg(fexpr, pass)
+	if msgArg == nil {
+		return 0, nil
+	}
+	keyValues, _ := keyValueArgs(fexpr, pass)
+	typeAndValue, ok := pass.TypesInfo.Types[msgArg]
+	if !ok || typeAndValue.Value == nil || typeAndValue.Value.Kind() != constant.String {
+		return 0, nil
+	}
+	parts, ok := parseFormat(constant.StringVal(typeAndValue.Value))
+	formatArgs := len(parts) - 1
+	if !ok || formatArgs == 0 {
+		return 0, nil
+	}
+	if formatArgs > len(keyValues) {
+		// Some values are missing.
+		return len(keyValues), nil
+	}
+
+	usedKeys := map[string]bool{}
+	for i := formatArgs; i < len(keyValues); i += 2 {
+		if lit, ok := keyValues[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if key, err := strconv.Unquote(lit.Value); err == nil {
+				usedKeys[key] = true
+			}
+		}
+	}
+	newArgs, ok := formatKeyValues(parts, keyValues[:formatArgs], usedKeys, errorKey, errorArg(fexpr, msgArg, pass), pass)
+	if !ok || fexpr.Ellipsis.IsValid() {
+		return formatArgs, nil
+	}
+	return formatArgs, []analysis.SuggestedFix{{
+		Message: "Turn format specifiers into key/value pairs",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     msgArg.Pos(),
+			End:     keyValues[formatArgs-1].End(),
+			NewText: []byte(strings.Join(newArgs, ", ")),
+		}},
+	}}
+}
+
+// formatKeyValues turns a format string, split by parseFormat, and its
+// arguments into the source code for a constant message followed by
+// key/value pairs. Keys are derived from the arguments and must not be in
+// the set of keys which are already used. Errors are logged with errorKey.
+// An argument which is the same as the error parameter of the call, if there
+// is one, is not logged twice.
+func formatKeyValues(parts []string, args []ast.Expr, usedKeys map[string]bool, errorKey string, errArg ast.Expr, pass *analysis.Pass) ([]string, bool) {
+	msg := messageFromFormat(parts)
+	if msg == "" {
+		return nil, false
+	}
+	result := []string{strconv.Quote(msg)}
+	keys := map[string]bool{}
+	errText := ""
+	if errArg != nil {
+		errText = formatNode(pass.Fset, errArg)
+	}
+	for _, arg := range args {
+		if errText != "" && formatNode(pass.Fset, arg) == errText {
+			continue
+		}
+		key := keyForExpr(arg)
+		if isError(pass.TypesInfo.TypeOf(arg)) {
+			key = errorKey
+		}
+		if key == "" || keys[key] || usedKeys[key] || !keyMatchRe.MatchString(key) {
+			return nil, false
+		}
+		keys[key] = true
+		result = append(result, strconv.Quote(key), formatNode(pass.Fset, arg))
+	}
+	return result, true
+}
+
+// errorArg returns the error parameter of a call like
+// logger.Error(err, msg) or klog.ErrorS(err, msg), i.e. the error which
+// directly precedes the message. It returns nil for other calls.
+func errorArg(fexpr *ast.CallExpr, msgArg ast.Expr, pass *analysis.Pass) ast.Expr {
+	for i := 1; i < len(fexpr.Args); i++ {
+		if fexpr.Args[i] == msgArg {
+			if isError(pass.TypesInfo.TypeOf(fexpr.Args[i-1])) {
+				return fexpr.Args[i-1]
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// parseFormat splits a printf-style format string into the text around its
+// verbs. The result always has one more entry than there are verbs. Formats
+// which use explicit argument indices or "*" for width or precision are not
+// supported because they do not map one verb to one argument.
+func parseFormat(format string) ([]string, bool) {
+	var parts []string
+	var text strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			text.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i < len(format) && format[i] == '%' {
+			text.WriteByte('%')
+			continue
+		}
+		// Skip flags, width and precision.
+		for i < len(format) && strings.IndexByte("+-# 0123456789.", format[i]) >= 0 {
+			i++
+		}
+		if i >= len(format) || format[i] == '[' || format[i] == '*' {
+			return nil, false
+		}
+		_, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		parts = append(parts, text.String())
+		text.Reset()
+	}
+	return append(parts, text.String()), true
+}
+
+// verbPlaceholder matches the position of a removed verb together with
+// punctuation that only makes sense when the value is part of the message,
+// like the "=" in "pod=%s" or the quotes in "%q" or "'%s'".
+var verbPlaceholder = regexp.MustCompile(`\s*[=:]?\s*["'(\[{]?\x00["')\]}]?`)
+
+// messageFromFormat turns the text around the verbs into a constant log
+// message. The message starts with a capital letter and has no trailing
+// punctuation.
+func messageFromFormat(parts []string) string {
+	msg := strings.Join(parts, "\x00")
+	msg = verbPlaceholder.ReplaceAllString(msg, " ")
+	// Punctuation which separated values, like the slash in "%s/%s", is
+	// not useful anymore.
+	var words []string
+	for _, word := range strings.Fields(msg) {
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			words = append(words, word)
+		}
+	}
+	msg = strings.TrimRight(strings.Join(words, " "), " .:,;=-")
+	r, size := utf8.DecodeRuneInString(msg)
+	if size == 0 {
+		return ""
+	}
+	return string(unicode.ToUpper(r)) + msg[size:]
+}
+
+// keyForExpr derives a key name from the expression that provides the value,
+// for example "podName" for pod.Name or pod.GetName(). It returns an empty
+// string for expressions where no sensible name can be derived.
+func keyForExpr(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return lowerFirst(expr.Name)
+	case *ast.ParenExpr:
+		return keyForExpr(expr.X)
+	case *ast.StarExpr:
+		return keyForExpr(expr.X)
+	case *ast.UnaryExpr:
+		if expr.Op != token.AND {
+			return ""
+		}
+		return keyForExpr(expr.X)
+	case *ast.IndexExpr:
+		return keyForExpr(expr.X)
+	case *ast.SelectorExpr:
+		root := keyForRoot(expr.X)
+		if root == "" {
+			return lowerFirst(expr.Sel.Name)
+		}
+		return root + upperFirst(expr.Sel.Name)
+	case *ast.CallExpr:
+		if len(expr.Args) > 0 {
+			return ""
+		}
+		selExpr, ok := expr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return ""
+		}
+		name := selExpr.Sel.Name
+		if name == "String" || name == "Error" {
+			return keyForExpr(selExpr.X)
+		}
+		name = strings.TrimPrefix(name, "Get")
+		if name == "" {
+			return ""
+		}
+		root := keyForRoot(selExpr.X)
+		if root == "" {
+			return lowerFirst(name)
+		}
+		return root + upperFirst(name)
+	}
+	return ""
+}
+
+// keyForRoot returns the name of the variable at the root of a selector
+// chain, so that pod.Spec.NodeName becomes "podNodeName" instead of
+// "podSpecNodeName".
+func keyForRoot(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.CallExpr:
+			expr = e.Fun
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.Ident:
+			return lowerFirst(e.Name)
+		default:
+			return ""
+		}
+	}
+}
+
+// lowerFirst converts the first letter to lower case unless the name starts
+// with an acronym like "UID".
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if size == 0 {
+		return ""
+	}
+	if next, _ := utf8.DecodeRuneInString(name[size:]); unicode.IsUpper(next) {
+		return name
+	}
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+func upperFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// isError checks whether the type implements the error interface.
+func isError(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(t, errorType)
+}
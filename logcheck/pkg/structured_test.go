@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"testing"
+)
+
+func TestMessageFromFormat(t *testing.T) {
+	for _, tc := range []struct {
+		format    string
+		expectOK  bool
+		expectMsg string
+	}{
+		{
+			format:    "starting",
+			expectOK:  true,
+			expectMsg: "Starting",
+		},
+		{
+			format:    "Failed to sync pod %s: %v",
+			expectOK:  true,
+			expectMsg: "Failed to sync pod",
+		},
+		{
+			format:    "Pod %q has uid=%s.\n",
+			expectOK:  true,
+			expectMsg: "Pod has uid",
+		},
+		{
+			format:    "Pod %s/%s is %5.2f%% done ('%s')",
+			expectOK:  true,
+			expectMsg: "Pod is done",
+		},
+		{
+			format:    "%v",
+			expectOK:  true,
+			expectMsg: "",
+		},
+		{
+			format: "%[2]s %[1]s",
+		},
+		{
+			format: "%*d",
+		},
+		{
+			format: "trailing %",
+		},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			parts, ok := parseFormat(tc.format)
+			if ok != tc.expectOK {
+				t.Fatalf("expected ok %v, got %v", tc.expectOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if msg := messageFromFormat(parts); msg != tc.expectMsg {
+				t.Errorf("expected message %q, got %q", tc.expectMsg, msg)
+			}
+		})
+	}
+}
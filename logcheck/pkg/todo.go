@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// todoBudget maps import paths to the number of klog.TODO calls which are
+// allowed in that package. The entry for the empty string applies to all
+// other packages. Without an entry, each call gets reported.
+type todoBudget map[string]int
+
+func (t todoBudget) String() string {
+	var entries []string
+	for pkgPath, budget := range t {
+		if pkgPath == "" {
+			entries = append(entries, strconv.Itoa(budget))
+			continue
+		}
+		entries = append(entries, fmt.Sprintf("%s=%d", pkgPath, budget))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// Set parses a comma-separated list of <import path>=<count> entries. A
+// <count> without import path is used for all other packages.
+func (t todoBudget) Set(value string) error {
+	clear(t)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pkgPath, count, found := strings.Cut(entry, "=")
+		if !found {
+			pkgPath, count = "", entry
+		}
+		budget, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || budget < 0 {
+			return fmt.Errorf("%q: count must be a non-negative number", entry)
+		}
+		t[strings.TrimSpace(pkgPath)] = budget
+	}
+	return nil
+}
+
+// lookup returns the budget for the package and whether there is one.
+func (t todoBudget) lookup(pkgPath string) (int, bool) {
+	if budget, ok := t[pkgPath]; ok {
+		return budget, true
+	}
+	budget, ok := t[""]
+	return budget, ok
+}
+
+// checkForTODO reports klog.TODO calls in files where the todo check is
+// enabled. If the package has a budget, only the call which exceeds it gets
+// reported, together with the total number of calls.
+func checkForTODO(pass *analysis.Pass, c *Config) {
+	var calls []*ast.CallExpr
+	for _, file := range pass.Files {
+		filename := fileKey(pass, file.Pos())
+		if !c.isEnabled(todoCheck, filename) {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if callExpr, ok := n.(*ast.CallExpr); ok {
+				if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "TODO" && isPackage(selExpr.X, "k8s.io/klog/v2", pass) {
+					calls = append(calls, callExpr)
+				}
+			}
+			return true
+		})
+	}
+
+	budget, ok := c.todoBudget.lookup(pass.Pkg.Path())
+	if !ok {
+		for _, call := range calls {
+			pass.Report(analysis.Diagnostic{
+				Pos:     call.Pos(),
+				Message: "klog.TODO marks code which still needs to be converted to contextual logging. Pass a logger or context instead.",
+			})
+		}
+		return
+	}
+	if len(calls) > budget {
+		pass.Report(analysis.Diagnostic{
+			Pos:     calls[budget].Pos(),
+			Message: fmt.Sprintf("klog.TODO is called %d times in package %s, which exceeds the budget of %d.", len(calls), pass.Pkg.Path(), budget),
+		})
+	}
+}
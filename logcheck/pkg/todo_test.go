@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pkg
+
+import (
+	"testing"
+)
+
+func TestTODOBudget(t *testing.T) {
+	for value, expect := range map[string]struct {
+		budget todoBudget
+		err    bool
+	}{
+		"":                    {budget: todoBudget{}},
+		"3":                   {budget: todoBudget{"": 3}},
+		"example.com/a=2":     {budget: todoBudget{"example.com/a": 2}},
+		"example.com/a=2, 0":  {budget: todoBudget{"example.com/a": 2, "": 0}},
+		"example.com/a = 2,,": {budget: todoBudget{"example.com/a": 2}},
+		"example.com/a=two":   {err: true},
+		"-1":                  {err: true},
+	} {
+		t.Run(value, func(t *testing.T) {
+			budget := todoBudget{}
+			err := budget.Set(value)
+			if expect.err {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if budget.String() != expect.budget.String() {
+				t.Errorf("expected %q, got %q", expect.budget.String(), budget.String())
+			}
+		})
+	}
+}
@@ -38,8 +38,9 @@ func (*analyzerPlugin) GetAnalyzers() []*analysis.Analyzer {
 var AnalyzerPlugin analyzerPlugin
 
 type settings struct {
-	Check  map[string]bool `json:"check"`
-	Config string          `json:"config"`
+	Check   map[string]bool   `json:"check"`
+	Config  string            `json:"config"`
+	Options map[string]string `json:"options"`
 }
 
 // New API, see https://github.com/golangci/golangci-lint/pull/3887.
@@ -71,6 +72,11 @@ func New(pluginSettings interface{}) ([]*analysis.Analyzer, error) {
 	if err := config.ParseConfig(s.Config); err != nil {
 		return nil, fmt.Errorf("parsing config: %v", err)
 	}
+	for name, value := range s.Options {
+		if err := analyzer.Flags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("option %s: %v", name, err)
+		}
+	}
 
 	return []*analysis.Analyzer{analyzer}, nil
 }
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This module is used to test the context-propagation check with a Go
+// version which does not have context.WithoutCancel yet.
+package contextPropagationGo120
+
+import (
+	"context"
+)
+
+func withContext(ctx context.Context) {
+	work(context.TODO()) // want `context.TODO\(\) ignores the logger in "ctx" and breaks contextual logging.`
+	go func() {
+		work(context.Background()) // want `context.Background\(\) ignores the logger in "ctx" and breaks contextual logging.`
+	}()
+}
+
+func work(ctx context.Context) {}
@@ -0,0 +1,3 @@
+module contextPropagationGo120
+
+go 1.20
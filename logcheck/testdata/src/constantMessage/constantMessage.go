@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// constant-message check.
+package constantMessage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+const prefix = "Pod "
+
+type podInfo struct {
+	Name string
+}
+
+func messages(logger logr.Logger, pod podInfo, name string) {
+	err := errors.New("fake error")
+	klog.InfoS("Pod is ready")
+	klog.InfoS(prefix + "is ready")
+	klog.InfoS(fmt.Sprintf("Pod %s is ready", pod.Name))               // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	klog.ErrorS(err, fmt.Sprintf("Sync failed: %v", name), "count", 1) // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	logger.Info("Syncing " + name)                                     // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	logger.Error(nil, err.Error())                                     // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	msg := "Pod is ready"
+	logger.V(1).Info(msg)                                                 // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	logger.Info(fmt.Sprintf("Pod %s is ready", pod.Name), "podName", "x") // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	logger.Error(err, fmt.Sprintf("Syncing %s failed: %v", name, err))    // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+}
+
+func wrapper(logger logr.Logger, msg string, kv ...interface{}) {
+	logger.Info(msg, kv...) // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	func() {
+		klog.InfoS(msg) // want `Log message should be a constant string. Move the variable parts into key/value pairs.`
+	}()
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// removal of logger parameters from functions which also accept a context.
+package contextAndLogger
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func process(ctx context.Context, logger logr.Logger, item string) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	logger.Info("Processing", "item", item)
+}
+
+func ignoreLogger(ctx context.Context, _ logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	process(ctx, klog.FromContext(ctx), "ignored")
+}
+
+type worker struct{}
+
+func (w worker) run(logger logr.Logger, ctx context.Context) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	logger.Info("Running")
+}
+
+// callback is used as a value and thus cannot be changed.
+func callback(ctx context.Context, logger logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	logger.Info("Callback")
+}
+
+var _ = callback
+
+type handler interface {
+	handle(ctx context.Context, logger logr.Logger) // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+}
+
+type myHandler struct{}
+
+// handle might implement handler and thus cannot be changed.
+func (h myHandler) handle(ctx context.Context, logger logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	logger.Info("Handling")
+}
+
+func callers(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	process(ctx, logger, "a")
+	process(ctx, klog.FromContext(ctx), "b")
+	process(ctx, logger.WithName("sub"), "c")
+	ignoreLogger(ctx, logger)
+	worker{}.run(logger, ctx)
+}
+
+func background() {
+	ctx := context.Background()
+	process(ctx, klog.Background(), "d")
+}
+
+// Process is exported, so callers in other packages cannot be updated.
+func Process(ctx context.Context, logger logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that. There is no suggested fix because other packages might use this exported function.`
+	logger.Info("Processing")
+}
+
+// Handle might implement an interface from some other package.
+func (h myHandler) Handle(ctx context.Context, logger logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that. There is no suggested fix because other packages might use this exported function.`
+	logger.Info("Handling")
+}
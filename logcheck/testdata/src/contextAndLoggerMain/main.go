@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// removal of logger parameters from exported functions in package main.
+package main
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func main() {
+	ctx := context.Background()
+	logger := klog.FromContext(ctx)
+	Process(ctx, logger)
+	myHandler{}.Handle(ctx, logger)
+}
+
+// Process cannot be called by other packages.
+func Process(ctx context.Context, logger logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	logger.Info("Processing")
+}
+
+type myHandler struct{}
+
+// Handle might implement an interface from some other package.
+func (h myHandler) Handle(ctx context.Context, logger logr.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that. There is no suggested fix because other packages might use this exported function.`
+	logger.Info("Handling")
+}
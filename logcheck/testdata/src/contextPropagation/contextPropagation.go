@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// context-propagation check.
+package contextPropagation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func noContext() {
+	logger := klog.Background()
+	ctx := context.Background()
+	logger.Info("ok")
+	work(ctx)
+}
+
+func withContext(ctx context.Context) {
+	logger := klog.Background() // want `klog.Background\(\) ignores the logger in "ctx" and breaks contextual logging.`
+	logger.Info("not ok")
+	work(context.TODO()) // want `context.TODO\(\) ignores the logger in "ctx" and breaks contextual logging.`
+}
+
+func withLogger(logger logr.Logger) {
+	klog.TODO().Info("not ok") // want `klog.TODO\(\) ignores the logger in "logger" and breaks contextual logging.`
+	work(context.Background()) // want `context.Background\(\) ignores the logger in "logger" and breaks contextual logging.`
+	work(klog.NewContext(context.Background(), logger))
+}
+
+func closures(ctx context.Context) {
+	f := func() {
+		klog.Background().Info("not ok") // want `klog.Background\(\) ignores the logger in "ctx" and breaks contextual logging.`
+	}
+	f()
+	go func() {
+		work(context.Background()) // want `context.Background\(\) ignores the logger in "ctx" and breaks contextual logging.`
+	}()
+	go func(ctx context.Context) {
+		work(context.Background()) // want `context.Background\(\) ignores the logger in "ctx" and breaks contextual logging.`
+	}(ctx)
+}
+
+func work(ctx context.Context) {}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// conversion of global klog calls to contextual logging.
+package contextualFix
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+var err = errors.New("fake error")
+
+func withContext(ctx context.Context, level klog.Level) {
+	klog.InfoS("Starting")                    // want `function "InfoS" should not be used, convert to contextual logging`
+	klog.V(2).InfoS("Details", "count", 1)    // want `function "V" should not be used, convert to contextual logging` `function "InfoS" should not be used, convert to contextual logging`
+	klog.V(level).InfoS("Details")            // want `function "V" should not be used, convert to contextual logging` `function "InfoS" should not be used, convert to contextual logging`
+	klog.ErrorS(err, "Failed", "count", 1)    // want `function "ErrorS" should not be used, convert to contextual logging`
+	klog.V(3).ErrorS(err, "Ignoring failure") // want `function "V" should not be used, convert to contextual logging` `function "ErrorS" should not be used, convert to contextual logging`
+	klog.Infof("Unstructured")                // want `function "Infof" should not be used, convert to contextual logging`
+	go func() {
+		klog.InfoS("In goroutine") // want `function "InfoS" should not be used, convert to contextual logging`
+	}()
+	func(ctx context.Context) {
+		klog.InfoS("Own context") // want `function "InfoS" should not be used, convert to contextual logging`
+	}(ctx)
+}
+
+func withLogger(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	logger.Info("Starting")
+	klog.InfoS("Done") // want `function "InfoS" should not be used, convert to contextual logging`
+}
+
+func withLoggerParameter(log logr.Logger) {
+	klog.V(4).InfoS("Done") // want `function "V" should not be used, convert to contextual logging` `function "InfoS" should not be used, convert to contextual logging`
+}
+
+func withOtherLogger(ctx context.Context, logger string) {
+	klog.InfoS("Done", "logger", logger) // want `function "InfoS" should not be used, convert to contextual logging`
+}
+
+func withoutContext() {
+	klog.InfoS("Done") // want `function "InfoS" should not be used, convert to contextual logging`
+}
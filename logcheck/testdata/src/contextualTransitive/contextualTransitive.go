@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// contextual-transitive check.
+package contextualTransitive
+
+import (
+	"example.com/helper"
+	klog "k8s.io/klog/v2"
+)
+
+func useHelper(h *helper.Helper) { // want useHelper:`calls contextualTransitive.useHelper -> helper.LogPod -> helper.logIt -> klog.V\(\).InfoS`
+	helper.LogPod("pod") // want `function "LogPod" calls klog functions which are not allowed in contextual code \(helper.LogPod -> helper.logIt -> klog.V\(\).InfoS\), convert to contextual logging`
+	h.Log()              // want `function "Log" calls klog functions which are not allowed in contextual code \(helper.Helper.Log -> klog.ErrorS\), convert to contextual logging`
+	helper.Contextual(klog.Background())
+}
+
+func useLocal() { // want useLocal:`calls contextualTransitive.useLocal -> contextualTransitive.useHelper -> helper.LogPod -> helper.logIt -> klog.V\(\).InfoS`
+	useHelper(nil) // want `function "useHelper" calls klog functions which are not allowed in contextual code \(contextualTransitive.useHelper -> helper.LogPod -> helper.logIt -> klog.V\(\).InfoS\), convert to contextual logging`
+}
+
+func contextual(logger klog.Logger) {
+	helper.Contextual(logger)
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// deprecations check and its suggested fixes.
+
+package deprecations
+
+import (
+	"log/slog"
+
+	"github.com/go-logr/logr/slogr"
+)
+
+func slogrDeprecations(handler slog.Handler) {
+	logger := slogr.NewLogr(handler) // want `Detected usage of deprecated helper "NewLogr". Please switch to "logr.FromSlogHandler" instead. It was deprecated in github.com/go-logr/logr v1.4.1.`
+	logger.Info("Created logger")
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// discarded-logger check.
+package discardedLogger
+
+import (
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func discarded(logger logr.Logger, klogger klog.Logger) {
+	logger.WithValues("pod", "x")         // want `The result of logger.WithValues is not used. It is a value, so the call has no effect.`
+	logger.WithName("x")                  // want `The result of logger.WithName is not used. It is a value, so the call has no effect.`
+	klogger.WithName("x")                 // want `The result of klogger.WithName is not used. It is a value, so the call has no effect.`
+	klog.LoggerWithName(logger, "x")      // want `The result of klog.LoggerWithName is not used. It is a value, so the call has no effect.`
+	klog.LoggerWithValues(logger, "x", 1) // want `The result of klog.LoggerWithValues is not used. It is a value, so the call has no effect.`
+	logger.V(4)                           // want `The result of logger.V is not used. It is a value, so the call has no effect.`
+	klog.V(4)                             // want `The result of klog.V is not used. It is a value, so the call has no effect.`
+	logger.V(4).WithValues("x", 1)        // want `The result of logger.V\(4\).WithValues is not used. It is a value, so the call has no effect.`
+	klog.FromContext(nil).WithName("x")   // want `The result of klog.FromContext\(nil\).WithName is not used. It is a value, so the call has no effect.`
+}
+
+func used(logger logr.Logger) logr.Logger {
+	logger = logger.WithValues("pod", "x")
+	logger.V(4).Info("hello")
+	klog.V(4).InfoS("hello")
+	if v := klog.V(4); v.Enabled() {
+		v.InfoS("hello")
+	}
+	return logger.WithName("x")
+}
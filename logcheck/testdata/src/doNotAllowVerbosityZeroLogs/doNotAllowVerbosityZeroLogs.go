@@ -44,6 +44,11 @@ func verbosityLogging() {
 	klog.V(0).InfoS("I'm logging at level 0.")         // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
 	klog.V(zeroConst).InfoS("I'm logging at level 0.") // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
 	klog.V(zeroVar).InfoS("I'm logging at level 0.")
+
+	// The V(0) cannot be removed here without changing the code.
+	_ = klog.V(0).Enabled()                         // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
+	klog.V(0).Error(nil, "I'm logging at level 0.") // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.` `Detected usage of deprecated helper "Error". Please switch to "ErrorS" instead. It was deprecated in k8s.io/klog/v2 v2.3.0.`
+
 	klog.V(1).Info("test log")
 	klog.V(1).Infof("test log")
 	klog.V(1).Infoln("test log")
@@ -63,6 +68,7 @@ func verbosityLogging() {
 	logger.V(0).Info("hello", "1", "2")       // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
 	logger.V(0).Error(nil, "hello", "1", "2") // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
 	logger.V(0).WithValues("1", "2")          // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
+	_ = logger.V(0).Enabled()                 // want `Logging with V\(0\) is semantically equivalent to the same expression without it and just causes unnecessary overhead. It should get removed.`
 
 	logger.V(1).Info("hello", "1", "2")
 	logger.V(1).Error(nil, "hello", "1", "2")
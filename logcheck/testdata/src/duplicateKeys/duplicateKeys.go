@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// detection of duplicate keys.
+package duplicateKeys
+
+import (
+	"context"
+
+	klog "k8s.io/klog/v2"
+)
+
+func duplicates(ctx context.Context, items []string) {
+	klog.InfoS("Test", "pod", 1, "pod", 2)       // want `Key "pod" is passed more than once.`
+	klog.ErrorS(nil, "Test", "pod", 1, "pod", 2) // want `Key "pod" is passed more than once.`
+	klog.V(1).InfoS("Test", "pod", 1, "node", 2)
+
+	logger := klog.FromContext(ctx)
+	logger.Info("Test", "pod", 1, "pod", 2) // want `Key "pod" is passed more than once.`
+	logger.WithValues("pod", 1, "pod", 2)   // want `Key "pod" is passed more than once.`
+
+	logger = logger.WithValues("pod", 1)
+	logger.Info("Test", "pod", 2)                 // want `Key "pod" was already added to the logger with WithValues.`
+	logger.V(1).Info("Test", "pod", 2)            // want `Key "pod" was already added to the logger with WithValues.`
+	logger.WithName("foo").Info("Test", "pod", 2) // want `Key "pod" was already added to the logger with WithValues.`
+	logger = logger.WithValues("pod", 2)          // want `Key "pod" was already added to the logger with WithValues.`
+
+	nodeLogger := klog.LoggerWithValues(logger, "node", 1)
+	nodeLogger.Info("Test", "node", 2, "pod", 3)              // want `Key "node" was already added to the logger with WithValues.` `Key "pod" was already added to the logger with WithValues.`
+	nodeLogger = klog.LoggerWithValues(nodeLogger, "node", 2) // want `Key "node" was already added to the logger with WithValues.`
+	logger.Info("Test", "node", 2)
+
+	var itemLogger = klog.LoggerWithName(nodeLogger, "item")
+	for _, item := range items {
+		func() {
+			itemLogger.Info("Test", "item", item, "node", 3) // want `Key "node" was already added to the logger with WithValues.`
+		}()
+	}
+
+	otherLogger := klog.FromContext(ctx)
+	otherLogger.Info("Test", "pod", 1, "node", 2)
+}
+
+var handler = func(ctx context.Context) {
+	logger := klog.LoggerWithValues(klog.FromContext(ctx), "pod", 1)
+	logger.Info("Test", "pod", 2) // want `Key "pod" was already added to the logger with WithValues.`
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helper hides klog calls inside functions for testing the
+// contextual-transitive check.
+package helper
+
+import (
+	klog "k8s.io/klog/v2"
+)
+
+type Helper struct{}
+
+func LogPod(name string) {
+	logIt(name)
+}
+
+func logIt(name string) {
+	klog.V(2).InfoS("Processing pod", "pod", name)
+}
+
+func (h *Helper) Log() {
+	klog.ErrorS(nil, "Failed")
+}
+
+func Contextual(logger klog.Logger) {
+	logger.Info("Contextual")
+}
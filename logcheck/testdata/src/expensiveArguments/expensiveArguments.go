@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// expensive-arguments check.
+package expensiveArguments
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+type object struct {
+	Name string
+}
+
+func (o object) String() string { return o.Name }
+
+func expensive(logger logr.Logger, obj object) {
+	logger.V(5).Info("Dump", "obj", fmt.Sprintf("%+v", obj)) // want `The fmt.Sprintf call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+	klog.V(4).InfoS("Dump", "obj", obj.String())             // want `The String call is evaluated even when V\(4\) is disabled. Check Enabled\(\) first.`
+	data, _ := json.Marshal(obj)
+	logger.V(6).Info("Dump", "items", []string{obj.Name}, "ptr", &object{}) // want `The allocation is evaluated even when V\(6\) is disabled. Check Enabled\(\) first.` `The allocation is evaluated even when V\(6\) is disabled. Check Enabled\(\) first.`
+	logger.V(4).Info("Dump", "json", string(must(json.Marshal(obj))))       // want `The json.Marshal call is evaluated even when V\(4\) is disabled. Check Enabled\(\) first.`
+
+	// Cheap arguments or low verbosity.
+	logger.V(5).Info("Dump", "obj", obj, "data", data, "value", object{})
+	logger.V(2).Info("Dump", "obj", fmt.Sprintf("%+v", obj))
+	logger.Info("Dump", "obj", fmt.Sprintf("%+v", obj))
+	logger.V(5).Info("Dump", "func", func() string { return fmt.Sprint(obj) })
+
+	// Guarded.
+	if loggerV := logger.V(5); loggerV.Enabled() {
+		loggerV.Info("Dump", "obj", fmt.Sprintf("%+v", obj))
+		logger.V(5).Info("Dump", "obj", obj.String())
+	}
+	if klogV := klog.V(5); klogV.Enabled() {
+		klog.V(5).InfoS("Dump", "obj", obj.String())
+	}
+}
+
+func earlyReturn(logger logr.Logger, obj object) {
+	if !logger.V(5).Enabled() {
+		return
+	}
+	logger.V(5).Info("Dump", "obj", fmt.Sprintf("%+v", obj))
+}
+
+func variables(logger logr.Logger, obj object) {
+	v := logger.V(5)
+	v.Info("Dump", "obj", fmt.Sprintf("%+v", obj)) // want `The fmt.Sprintf call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+	klogV := klog.V(5)
+	klogV.InfoS("Dump", "obj", obj.String()) // want `The String call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+
+	if v.Enabled() {
+		v.Info("Dump", "obj", fmt.Sprintf("%+v", obj))
+		logger.V(5).Info("Dump", "obj", obj.String())
+	}
+	if klogV.Enabled() {
+		klogV.InfoS("Dump", "obj", obj.String())
+	}
+}
+
+func otherGuards(logger, other logr.Logger, obj object) {
+	if loggerV := logger.V(6); loggerV.Enabled() {
+		logger.V(5).Info("Dump", "obj", fmt.Sprintf("%+v", obj))
+	}
+	if loggerV := logger.V(2); loggerV.Enabled() {
+		logger.V(5).Info("Dump", "obj", fmt.Sprintf("%+v", obj)) // want `The fmt.Sprintf call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+	}
+	if otherV := other.V(5); otherV.Enabled() {
+		logger.V(5).Info("Dump", "obj", fmt.Sprintf("%+v", obj)) // want `The fmt.Sprintf call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+	}
+	if logger.Enabled() {
+		logger.V(5).Info("Dump", "obj", fmt.Sprintf("%+v", obj)) // want `The fmt.Sprintf call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+	}
+	if !klog.V(4).Enabled() {
+		return
+	}
+	klog.V(5).InfoS("Dump", "obj", obj.String()) // want `The String call is evaluated even when V\(5\) is disabled. Check Enabled\(\) first.`
+}
+
+func errorCalls(logger logr.Logger, obj object, err error) {
+	// Error always logs, so the arguments are needed.
+	logger.V(5).Error(err, "Dump", "obj", fmt.Sprintf("%+v", obj)) // want `V\(\).Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V\(\).Info\(..., "err", err\).`
+}
+
+func must(data []byte, err error) []byte {
+	return data
+}
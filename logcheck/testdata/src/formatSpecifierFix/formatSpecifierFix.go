@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// conversion of format specifiers into key/value pairs.
+package formatSpecifierFix
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+type podInfo struct {
+	Name, Namespace string
+}
+
+func formatSpecifiers(logger logr.Logger, pod podInfo, n int, duration time.Duration, node string) {
+	err := errors.New("fake error")
+	klog.InfoS("got %d pods", n)                                      // want `logging function "InfoS" should not use format specifier "%d"`
+	klog.ErrorS(err, "failed to sync pod %s", pod.Name, "node", node) // want `logging function "ErrorS" should not use format specifier "%s"`
+	logger.Info("pod %s/%s deleted", pod.Namespace, pod.Name)         // want `logging function "Info" should not use format specifier "%s"`
+	klog.V(2).InfoS("took %v", duration, "größe", node)               // want `logging function "InfoS" should not use format specifier "%v"` `Key positional arguments "größe" are expected to be lowerCamelCase alphanumeric strings. Please remove any non-Latin characters.`
+	klog.InfoS("node %s", node, "node", "other")                      // want `logging function "InfoS" should not use format specifier "%s"`
+	logger.Error(err, "sync failed: %v", err)                         // want `logging function "Error" should not use format specifier "%v"`
+	klog.InfoS("sync failed: %v", err)                                // want `logging function "InfoS" should not use format specifier "%v"`
+	klog.InfoS("got %d pods on %s", n)                                // want `logging function "InfoS" should not use format specifier "%d"`
+}
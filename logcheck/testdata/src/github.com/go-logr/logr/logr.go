@@ -20,6 +20,7 @@ package logr
 
 import (
 	"context"
+	"log/slog"
 )
 
 type Logger struct{}
@@ -34,3 +35,11 @@ func (l Logger) Error(err error, msg string, kv ...interface{}) {}
 func NewContext(ctx context.Context, logger Logger) context.Context {
 	return nil
 }
+
+func FromSlogHandler(handler slog.Handler) Logger {
+	return Logger{}
+}
+
+func ToSlogHandler(logger Logger) slog.Handler {
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package slogr provides empty stubs for github.com/go-logr/logr/slogr for
+// testing with golang.org/x/tools/go/analysis/analysistest.
+package slogr
+
+import (
+	"log/slog"
+
+	"github.com/go-logr/logr"
+)
+
+// Deprecated: use logr.FromSlogHandler instead.
+func NewLogr(handler slog.Handler) logr.Logger {
+	return logr.FromSlogHandler(handler)
+}
+
+// Deprecated: use logr.ToSlogHandler instead.
+func NewSlogHandler(logger logr.Logger) slog.Handler {
+	return logr.ToSlogHandler(logger)
+}
+
+// Deprecated: use logr.ToSlogHandler instead.
+func ToSlogHandler(logger logr.Logger) slog.Handler {
+	return logr.ToSlogHandler(logger)
+}
+
+type SlogSink interface {
+	slog.Handler
+}
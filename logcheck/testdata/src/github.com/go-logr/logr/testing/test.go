@@ -0,0 +1,30 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides empty stubs for github.com/go-logr/logr/testing
+// for testing with golang.org/x/tools/go/analysis/analysistest.
+package testing
+
+import "github.com/go-logr/logr/testr"
+
+// Deprecated.  See github.com/go-logr/logr/testr.New instead.
+var NewTestLogger = testr.New
+
+// Deprecated.  See github.com/go-logr/logr/testr.Options instead.
+type Options = testr.Options
+
+// Deprecated.  See github.com/go-logr/logr/testr.NewWithOptions instead.
+var NewTestLoggerWithOptions = testr.NewWithOptions
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testr provides empty stubs for github.com/go-logr/logr/testr for
+// testing with golang.org/x/tools/go/analysis/analysistest.
+package testr
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type Options struct {
+	Verbosity int
+}
+
+func New(t *testing.T) logr.Logger {
+	return logr.Logger{}
+}
+
+func NewWithOptions(t *testing.T, opts Options) logr.Logger {
+	return logr.Logger{}
+}
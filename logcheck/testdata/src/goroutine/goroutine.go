@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// goroutine check.
+package goroutine
+
+import (
+	"context"
+
+	"example.com/helper"
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func withContext(ctx context.Context) { // want withContext:`calls goroutine.withContext -> klog.InfoS`
+	go func() {
+		klog.InfoS("Started")             // want `klog.InfoS in a goroutine ignores the logger in "ctx". Pass it into the goroutine and log through it.`
+		klog.V(2).InfoS("Started")        // want `klog.V\(\).InfoS in a goroutine ignores the logger in "ctx". Pass it into the goroutine and log through it.`
+		klog.Background().Info("Started") // want `klog.Background in a goroutine ignores the logger in "ctx". Pass it into the goroutine and log through it.`
+		klog.FromContext(ctx).Info("Started")
+	}()
+	go worker()             // want `The goroutine worker logs through klog.ErrorS and ignores the logger in "ctx". Pass it to the function.`
+	go helper.LogPod("pod") // want `The goroutine LogPod logs through helper.logIt -> klog.V\(\).InfoS and ignores the logger in "ctx". Pass it to the function.`
+	go indirectWorker()     // want `The goroutine indirectWorker logs through goroutine.logFailure -> klog.ErrorS and ignores the logger in "ctx". Pass it to the function.`
+	go func() {
+		worker()             // want `worker in a goroutine logs through klog.ErrorS and ignores the logger in "ctx". Pass it into the goroutine and log through it.`
+		helper.LogPod("pod") // want `LogPod in a goroutine logs through helper.logIt -> klog.V\(\).InfoS and ignores the logger in "ctx". Pass it into the goroutine and log through it.`
+		contextualWorker(ctx)
+	}()
+	go contextualWorker(ctx)
+}
+
+func withLogger(logger logr.Logger) { // want withLogger:`calls goroutine.withLogger -> klog.ErrorS`
+	go func() {
+		klog.ErrorS(nil, "Failed") // want `klog.ErrorS in a goroutine ignores the logger in "logger". Pass it into the goroutine and log through it.`
+		logger.Info("Started")
+	}()
+}
+
+func withoutLogger() { // want withoutLogger:`calls goroutine.withoutLogger -> klog.InfoS`
+	go func() {
+		klog.InfoS("Started")
+	}()
+	go worker()
+}
+
+func worker() { // want worker:`calls goroutine.worker -> klog.ErrorS`
+	klog.ErrorS(nil, "Failed")
+}
+
+func indirectWorker() { // want indirectWorker:`calls goroutine.indirectWorker -> goroutine.logFailure -> klog.ErrorS`
+	logFailure()
+}
+
+func logFailure() { // want logFailure:`calls goroutine.logFailure -> klog.ErrorS`
+	klog.ErrorS(nil, "Failed")
+}
+
+func contextualWorker(ctx context.Context) {
+	klog.FromContext(ctx).Info("Started")
+}
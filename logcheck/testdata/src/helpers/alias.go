@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This file is used to test the suggested
+// fixes for the with-helpers check when klog is imported under a different
+// name.
+
+package helpers
+
+import (
+	"github.com/go-logr/logr"
+	klogv2 "k8s.io/klog/v2"
+)
+
+func aliasedKlog(logger logr.Logger, kvs []interface{}) logr.Logger {
+	logger = logger.WithName("foo").WithValues("a", "b") // want `function "WithValues" should be called through klogr.LoggerWithValues` `function "WithName" should be called through klogr.LoggerWithName`
+	logger = logger.V(1).WithValues(kvs...)              // want `function "WithValues" should be called through klogr.LoggerWithValues`
+	return klogv2.LoggerWithValues(logger)
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package klogr provides empty stubs for k8s.io/klog/v2/klogr for testing
+// with golang.org/x/tools/go/analysis/analysistest.
+package klogr
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// Deprecated: this uses a custom, out-dated output format. Use textlogger.NewLogger instead.
+func New() logr.Logger {
+	return logr.Logger{}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package textlogger provides empty stubs for k8s.io/klog/v2/textlogger for
+// testing with golang.org/x/tools/go/analysis/analysistest.
+package textlogger
+
+import (
+	"github.com/go-logr/logr"
+)
+
+type Config struct{}
+
+func NewConfig() *Config {
+	return &Config{}
+}
+
+func NewLogger(c *Config) logr.Logger {
+	return logr.Logger{}
+}
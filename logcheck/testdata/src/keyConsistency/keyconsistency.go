@@ -0,0 +1,40 @@
+// want package:"key inventory: count, err, node, pod, podObj"
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// key-consistency check.
+package keyConsistency
+
+import (
+	"example.com/a"
+	"example.com/api"
+	klog "k8s.io/klog/v2"
+)
+
+func keys(pod *api.Pod, node api.Node, err error) {
+	a.LogPod(pod, 1)
+	klog.InfoS("Same key and type as in the other package", "pod", pod, "count", 2)
+	klog.InfoS("Different integer type", "count", int64(3))
+	klog.InfoS("Different key", "podObj", pod)     // want `Values of type \*example.com/api.Pod are logged with key "podObj" here and with key "pod" at example.com/a/a.go:27.`
+	klog.InfoS("Unrelated type", "count", "three") // want `Key "count" is used for string here and for the unrelated type int at example.com/a/a.go:27.`
+	klog.InfoS("Node", "node", node)
+	klog.InfoS("Unrelated type in the same package", "node", node.Name) // want `Key "node" is used for string here and for the unrelated type example.com/api.Node at keyConsistency/keyconsistency.go:36.`
+	klog.InfoS("Interfaces are related to everything", "node", interface{}(nil))
+	klog.ErrorS(err, "Errors are not objects", "err", err)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// suggested fixes for keys which do not follow the naming guidelines.
+
+package keyFix
+
+import (
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func keyFix(logger logr.Logger) {
+	klog.InfoS("Pod started", "pod_name", "foo")      // want `Key positional arguments "pod_name" are expected to be alphanumeric and start with either one lowercase or two uppercase letters.`
+	logger.Info("Pod stopped", "pod_name", "foo")     // want `Key positional arguments "pod_name" are expected to be alphanumeric and start with either one lowercase or two uppercase letters.`
+	klog.ErrorS(nil, "Pod failed", "Pod-UID", "1234") // want `Key positional arguments "Pod-UID" are expected to be alphanumeric and start with either one lowercase or two uppercase letters.`
+
+	// Cannot be converted.
+	klog.InfoS("Pod started", "测试", "foo") // want `Key positional arguments "测试" are expected to be alphanumeric and start with either one lowercase or two uppercase letters.`
+}
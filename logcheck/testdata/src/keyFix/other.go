@@ -0,0 +1,29 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// suggested fixes for keys which do not follow the naming guidelines.
+
+package keyFix
+
+import (
+	"github.com/go-logr/logr"
+)
+
+func otherFile(logger logr.Logger) logr.Logger {
+	return logger.WithValues("pod_name", "bar") // want `Key positional arguments "pod_name" are expected to be alphanumeric and start with either one lowercase or two uppercase letters.`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// kobj check.
+package kobj
+
+import (
+	"example.com/api"
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func kobj(logger logr.Logger, pod *api.Pod, pods []*api.Pod, node api.Node, names []string, obj interface{}) {
+	klog.InfoS("Good", "pod", klog.KObj(pod), "pods", klog.KObjSlice(pods), "obj", klog.KObjSlice(obj))
+	klog.InfoS("Good", "pod", klog.KRef(pod.Namespace, pod.Name), "node", klog.KRef("", node.GetName()))
+	klog.InfoS("Not KMetadata", "name", klog.KObj(names))                      // want `klog.KObj needs a value which implements klog.KMetadata \(GetName and GetNamespace\). \[\]string does not.`
+	klog.InfoS("Not a slice", "pod", klog.KObjSlice(pod))                      // want `klog.KObjSlice needs a slice, not \*example.com/api.Pod.`
+	klog.InfoS("Not KMetadata", "names", klog.KObjSlice(names))                // want `klog.KObjSlice needs a slice of values which implement klog.KMetadata \(GetName and GetNamespace\). string does not.`
+	klog.InfoS("Swapped", "pod", klog.KRef(pod.Name, pod.Namespace))           // want `The parameters of klog.KRef are namespace and name, in that order. These look swapped.`
+	klog.InfoS("Swapped", "pod", klog.KRef(pod.GetName(), pod.GetNamespace())) // want `The parameters of klog.KRef are namespace and name, in that order. These look swapped.`
+	klog.InfoS("Whole object", "pod", pod)                                     // want `The value for key "pod" is a whole \*example.com/api.Pod object. Log klog.KObj\(...\) instead unless the entire object is needed.`
+	logger.Info("Whole object", "node", &node)                                 // want `The value for key "node" is a whole \*example.com/api.Node object. Log klog.KObj\(...\) instead unless the entire object is needed.`
+	logger.Info("Whole object without fix", "node", node)                      // want `The value for key "node" is a whole example.com/api.Node object. Log klog.KObj\(...\) instead unless the entire object is needed.`
+	logger.Info("Metadata only", "meta", pod.ObjectMeta)                       // want `The value for key "meta" is a whole example.com/api.ObjectMeta object. Log klog.KObj\(...\) instead unless the entire object is needed.`
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// message check.
+package message
+
+import (
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+const lowerMessage = "starting"
+
+func messages(logger logr.Logger) {
+	klog.InfoS("Pod is ready")
+	klog.InfoS("starting pod")       // want `Log message should start with a capital letter.`
+	klog.ErrorS(nil, "Sync failed.") // want `Log message should not end with a period.`
+	klog.V(1).InfoS(" Waiting...\n") // want `Log message should not have leading or trailing whitespace.`
+	logger.Info("")                  // want `Log message should not be empty.`
+	logger.Error(nil, "  ")          // want `Log message should not be empty.`
+	logger.V(2).Info("gRPC call failed")
+	logger.Info(`done.`) // want `Log message should not end with a period.`
+	logger.Info("Waiting...")
+	logger.Info(lowerMessage)        // want `Log message should start with a capital letter.`
+	logger.Info("starting" + " pod") // want `Log message should start with a capital letter.`
+	logger.WithValues("key", "value").Info("Done", "count", 1)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// message-key-values check.
+package messageKeyValues
+
+import (
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+func messages(logger logr.Logger, name string, count int) {
+	klog.InfoS("Syncing pod=" + name)           // want `Log message should not contain key/value pairs like "pod=". Pass them as additional parameters instead.`
+	klog.ErrorS(nil, "Sync failed, pod: "+name) // want `Log message should not contain key/value pairs like "pod:". Pass them as additional parameters instead.`
+	logger.Info("done: count=5")                // want `Log message should not contain key/value pairs like "count=". Pass them as additional parameters instead.`
+	logger.Info("Deleted pod: \"foo\"")         // want `Log message should not contain key/value pairs like "pod:". Pass them as additional parameters instead.`
+	logger.V(1).Info("Pods: 5")                 // want `Log message should not contain key/value pairs like "Pods:". Pass them as additional parameters instead.`
+	logger.Info("Syncing pod="+name, "pod", 1)  // want `Log message should not contain key/value pairs like "pod=". Pass them as additional parameters instead.`
+	logger.Info("Sync failed: timeout")
+	logger.Info("Comparing a==b")
+	logger.Info("See http://example.com")
+	logger.Info("Syncing pod", "pod", name)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// package-init check with package-init-follow-calls.
+package followCalls
+
+import (
+	klog "k8s.io/klog/v2"
+)
+
+var logger = klog.Background()
+
+var componentLogger = klog.Background()
+
+var count = compute() // want `compute logs during package initialization, before logging is configured \(followCalls.compute -> followCalls.logIt -> logger.Info\).`
+
+func init() {
+	setup() // want `setup logs during package initialization, before logging is configured \(followCalls.setup -> followCalls.report -> klog.ErrorS\).`
+	configure()
+}
+
+func setup() {
+	report()
+}
+
+func report() {
+	klog.ErrorS(nil, "Failed")
+}
+
+func compute() int {
+	return logIt()
+}
+
+func logIt() int {
+	logger.Info("Computing")
+	return 1
+}
+
+// configure only creates a logger.
+func configure() {
+	componentLogger = klog.LoggerWithValues(componentLogger, "configured", true)
+}
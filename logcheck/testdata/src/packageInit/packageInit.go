@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// package-init check. Calls of functions which log are in the followCalls
+// package, which is only checked with package-init-follow-calls.
+package packageInit
+
+import (
+	"context"
+	"net/http"
+
+	klog "k8s.io/klog/v2"
+)
+
+var logger = klog.Background()
+
+var ctxLogger = klog.FromContext(context.Background())
+
+var valuesLogger = klog.LoggerWithValues(logger, "component", "test")
+
+var initialized = func() bool {
+	klog.V(2).InfoS("Initializing")                                   // want `klog.V\(\).InfoS is called during package initialization, before logging is configured.`
+	klog.V(2).Infof("Initializing")                                   // want `klog.V\(\).Infof is called during package initialization, before logging is configured.`
+	klog.FromContext(context.Background()).Error(nil, "Initializing") // want `klog.FromContext\(context.Background\(\)\).Error is called during package initialization, before logging is configured.`
+	return true
+}()
+
+func init() {
+	klog.InfoS("Starting")              // want `klog.InfoS is called during package initialization, before logging is configured.`
+	klog.Warning("Starting")            // want `klog.Warning is called during package initialization, before logging is configured.`
+	logger.Info("Starting")             // want `logger.Info is called during package initialization, before logging is configured.`
+	ctxLogger.Info("Starting")          // want `ctxLogger.Info is called during package initialization, before logging is configured.`
+	valuesLogger.V(1).Info("Starting")  // want `valuesLogger.V\(1\).Info is called during package initialization, before logging is configured.`
+	valuesLogger.Error(nil, "Starting") // want `valuesLogger.Error is called during package initialization, before logging is configured.`
+	valuesLogger = valuesLogger.WithValues("phase", "init")
+	http.HandleFunc("/", func(http.ResponseWriter, *http.Request) {
+		klog.InfoS("Request")
+	})
+	logger = klog.LoggerWithName(logger, "init")
+}
+
+func notInit() {
+	klog.InfoS("Running")
+}
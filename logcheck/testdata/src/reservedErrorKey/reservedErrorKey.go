@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// detection of reserved keys when errors get logged with a different key.
+package reservedErrorKey
+
+import (
+	"errors"
+
+	klog "k8s.io/klog/v2"
+)
+
+func reservedKeys() {
+	err := errors.New("fake error")
+	klog.InfoS("Test", "err", err) // want `Key "err" is reserved for the log output format and should not be used.`
+	klog.InfoS("Test", "error", err)
+	klog.InfoS("Test", "error", "failed") // want `Key "error" is reserved for values of type error.`
+}
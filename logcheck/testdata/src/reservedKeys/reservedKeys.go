@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// detection of keys which are reserved for the log output format.
+package reservedKeys
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+const levelKey = "level"
+
+func reservedKeys(logger logr.Logger) {
+	err := errors.New("fake error")
+	klog.InfoS("Test", "ts", 1)                // want `Key "ts" is reserved for the log output format and should not be used.`
+	klog.ErrorS(err, "Test", "msg", "hello")   // want `Key "msg" is reserved for the log output format and should not be used.`
+	klog.V(1).InfoS("Test", "pod", 1, "v", 2)  // want `Key "v" is reserved for the log output format and should not be used.`
+	logger.Info("Test", "caller", "me")        // want `Key "caller" is reserved for the log output format and should not be used.`
+	logger.WithValues("logger", "other")       // want `Key "logger" is reserved for the log output format and should not be used.`
+	klog.LoggerWithValues(logger, levelKey, 5) // want `Key "level" is reserved for the log output format and should not be used.` `Key positional arguments are expected to be inlined constant strings. Please replace levelKey provided with string value.`
+	logger.Info("Test", "err", "failed")       // want `Key "err" is reserved for values of type error.`
+	logger.Info("Test", "err", err)
+	klog.InfoS("Test", "err", nil) // want `Key "err" is reserved for values of type error.`
+	klog.InfoS("Test", "timestamp", 1, "message", "hello")
+}
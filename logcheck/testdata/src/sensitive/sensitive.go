@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// sensitive check.
+package sensitive
+
+import (
+	"github.com/go-logr/logr"
+	klog "k8s.io/klog/v2"
+)
+
+type credentials struct {
+	User     string
+	Password string `datapolicy:"password"`
+}
+
+type embedded struct {
+	credentials
+	Name string
+}
+
+type nested struct {
+	Items []*credentials
+}
+
+type safe struct {
+	Password string `datapolicy:"password"`
+}
+
+func (s safe) String() string { return "<redacted>" }
+
+type pointerSafe struct {
+	Password string `datapolicy:"password"`
+}
+
+func (s *pointerSafe) String() string { return "<redacted>" }
+
+type recursive struct {
+	Next *recursive
+	Name string
+}
+
+type config struct {
+	Tokens map[string]token
+}
+
+type token struct {
+	Value string `datapolicy:"token"`
+}
+
+func sensitive(logger logr.Logger, c credentials, e embedded, n nested, s safe, p pointerSafe, r recursive, cfg *config) {
+	klog.InfoS("Test", "user", c.User)
+	klog.InfoS("Test", "credentials", c)  // want `The type sensitive.credentials contains Password with datapolicy "password", which should not be logged.`
+	klog.InfoS("Test", "credentials", &c) // want `The type \*sensitive.credentials contains Password with datapolicy "password", which should not be logged.`
+	logger.Info("Test", "embedded", e)    // want `The type sensitive.embedded contains credentials.Password with datapolicy "password", which should not be logged.`
+	logger.WithValues("nested", n)        // want `The type sensitive.nested contains Items.Password with datapolicy "password", which should not be logged.`
+	logger.Info("Test", "config", cfg)    // want `The type \*sensitive.config contains Tokens.Value with datapolicy "token", which should not be logged.`
+	logger.Info("Test", "safe", s, "recursive", r)
+	klog.ErrorS(nil, "Test", "password", c.Password)              // want `Key "password" indicates sensitive data which should not be logged.`
+	klog.InfoS("Test", "adminPassword", "x", "bearer_token", "y") // want `Key "adminPassword" indicates sensitive data which should not be logged.` `Key "bearer_token" indicates sensitive data which should not be logged.`
+	klog.InfoS("Test", "passwordHash", "x", "tokenValue", "y")    // want `Key "passwordHash" indicates sensitive data which should not be logged.` `Key "tokenValue" indicates sensitive data which should not be logged.`
+	klog.InfoS("Test", "secretName", "x", "tokenFile", "y")
+	klog.InfoS("Test", "secretNamespace", "x", "secretRef", "y", "keyPath", "z")
+	logger.Info("Test", "pointerSafe", &p)
+	logger.Info("Test", "pointerSafe", p) // want `The type sensitive.pointerSafe contains Password with datapolicy "password", which should not be logged.`
+}
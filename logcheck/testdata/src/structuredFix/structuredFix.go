@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// suggested fixes for unstructured logging calls.
+
+package structuredFix
+
+import (
+	"errors"
+
+	klog "k8s.io/klog/v2"
+)
+
+type pod struct {
+	Name      string
+	Namespace string
+	UID       string
+	Spec      spec
+}
+
+type spec struct {
+	NodeName string
+}
+
+func (p pod) GetName() string {
+	return p.Name
+}
+
+func structuredFix(pod pod, count int) {
+	err := errors.New("fake error")
+	format := "dynamic %s"
+
+	klog.Infof("Starting pod %s", pod.Name)                                        // want `unstructured logging function "Infof" should not be used`
+	klog.V(2).Infof("Pod %s/%s has uid=%s", pod.Namespace, pod.GetName(), pod.UID) // want `unstructured logging function "Infof" should not be used`
+	klog.Warningf("Found %d pods on node %q.", count, pod.Spec.NodeName)           // want `unstructured logging function "Warningf" should not be used`
+	klog.Errorf("Failed to sync pod %s: %v", pod.Name, err)                        // want `unstructured logging function "Errorf" should not be used`
+	klog.Errorf("Pod %s not ready (%d%%)", pod.Name, count)                        // want `unstructured logging function "Errorf" should not be used`
+	klog.Infof("Sync failed: %v\n", err)                                           // want `unstructured logging function "Infof" should not be used`
+
+	// These cannot be converted automatically.
+	klog.Infof(format, pod.Name)                     // want `unstructured logging function "Infof" should not be used`
+	klog.Infof("Pod %s", pod.Name, count)            // want `unstructured logging function "Infof" should not be used`
+	klog.Infof("Count %d", count+1)                  // want `unstructured logging function "Infof" should not be used`
+	klog.Infof("Pods %s and %s", pod.Name, pod.Name) // want `unstructured logging function "Infof" should not be used`
+	klog.Infof("%[1]s", pod.Name)                    // want `unstructured logging function "Infof" should not be used`
+	klog.Infof("%v", pod)                            // want `unstructured logging function "Infof" should not be used`
+	klog.Info("Pod ", pod.Name)                      // want `unstructured logging function "Info" should not be used`
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test that
+// klog.Logger, which is an alias for logr.Logger, is recognized.
+package typeAliases
+
+import (
+	"context"
+
+	klog "k8s.io/klog/v2"
+)
+
+func withAlias(ctx context.Context, logger klog.Logger) { // want `A function should accept either a context or a logger, but not both. Having both makes calling the function harder because it must be defined whether the context must contain the logger and callers have to follow that.`
+	logger = logger.WithName("foo") // want `function "WithName" should be called through klogr.LoggerWithName`
+	logger.Info("hello")
+}
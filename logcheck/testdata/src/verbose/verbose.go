@@ -45,6 +45,29 @@ func verboseLogging() {
 		l.V(1).Info("I'm logging at level 1.")
 	}
 
+	if logger.V(4).Enabled() { // want `the result of logger.V should be stored in a variable and then be used multiple times: if logger := logger.V\(\); logger.Enabled\(\) { ... logger.Info ... }`
+		logger.V(4).Info("I'm logging at level 4.")
+	} else {
+		klog.InfoS("Not logging at level 4.")
+	}
+
+	// These cannot be fixed automatically because the new variable
+	// would shadow a variable that is used inside the if statement.
+	klogV := klog.V(5)
+	if klog.V(1).Enabled() { // want `the result of klog.V should be stored in a variable and then be used multiple times: if klogV := klog.V\(\); klogV.Enabled\(\) { ... klogV.Info ... }`
+		klog.V(1).InfoS("I'm logging at level 1.")
+		klogV.InfoS("I'm logging at level 5.")
+	}
+	if l.V(3).Enabled() { // want `the result of l.V should be stored in a variable and then be used multiple times: if l := l.V\(\); l.Enabled\(\) { ... l.Info ... }`
+		l.V(3).Info("I'm logging at level 3.")
+		l.V(5).Info("I'm logging at level 5.")
+	}
+
+	// There is already an init statement.
+	if err := error(nil); l.V(3).Enabled() { // want `the result of l.V should be stored in a variable and then be used multiple times: if l := l.V\(\); l.Enabled\(\) { ... l.Info ... }`
+		l.V(3).Info("I'm logging at level 3.", "err", err)
+	}
+
 	if l := l.V(2); l.Enabled() {
 		l.Info("I'm logging at level 2.")
 	}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This fake package is created as golang.org/x/tools/go/analysis/analysistest
+// expects it to be here for loading. This package is used to test the
+// suggested fixes for the verbosity-error check.
+
+package verbosityError
+
+import (
+	"errors"
+
+	"github.com/go-logr/logr"
+)
+
+func verbosityError(logger logr.Logger) {
+	err := errors.New("fake error")
+	kvs := []interface{}{"podName", "foo"}
+
+	logger.V(5).Error(err, "Sync failed")                   // want `V\(\).Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V\(\).Info\(..., "error", err\).`
+	logger.V(5).Error(err, "Sync failed", "podName", "foo") // want `V\(\).Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V\(\).Info\(..., "error", err\).`
+	logger.WithName("sync").V(1).Error(nil, "Sync failed")  // want `V\(\).Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V\(\).Info\(..., "error", err\).`
+	logger.V(5).Error(err, "Sync failed", kvs...)           // want `V\(\).Error ignores the verbosity and always logs. Use only Error if that is desired, otherwise V\(\).Info\(..., "error", err\).`
+}